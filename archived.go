@@ -0,0 +1,33 @@
+package main
+
+import "fmt"
+
+// ArchivedCheck warns when origin's GitHub repo is archived or disabled, so
+// pushes will be rejected. It makes an extra API call per repo (cached
+// afterwards), so it only runs when the online config option is set.
+type ArchivedCheck struct{}
+
+func (c *ArchivedCheck) Name() string { return "archived" }
+
+func (c *ArchivedCheck) Check(repo *Repo) []Result {
+	if !repo.Config.Online {
+		return nil
+	}
+	owner, repoName := parseGitHubRepo(repo.RemoteURL("origin"))
+	if owner == "" {
+		return nil
+	}
+	state := repo.OriginReadOnly()
+	if state == "" {
+		return nil
+	}
+	return []Result{{
+		Name:    "github/archived",
+		Status:  StatusWarn,
+		Message: fmt.Sprintf("origin %s/%s is %s on GitHub; pushes will be rejected", owner, repoName, state),
+	}}
+}
+
+func (c *ArchivedCheck) Fix(_ *Repo, results []Result) []Result {
+	return results
+}
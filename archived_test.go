@@ -0,0 +1,24 @@
+package main
+
+import "testing"
+
+func TestArchivedCheckUsesCache(t *testing.T) {
+	r := newTestRepo(t)
+	r.git("remote", "add", "origin", "https://github.com/me/old.git")
+	r.git("config", "remote.origin.gh-archived", "archived")
+
+	if results := (&ArchivedCheck{}).Check(r.Repo); len(results) != 0 {
+		t.Errorf("online disabled: got %+v, want none", results)
+	}
+
+	r.Config.Online = true
+	got, ok := resultByName((&ArchivedCheck{}).Check(r.Repo), "github/archived")
+	if !ok || got.Status != StatusWarn {
+		t.Errorf("github/archived = %+v, want warn", got)
+	}
+
+	r.git("config", "remote.origin.gh-archived", "none")
+	if results := (&ArchivedCheck{}).Check(r.Repo); len(results) != 0 {
+		t.Errorf("cached none: got %+v, want none", results)
+	}
+}
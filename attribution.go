@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -25,6 +27,8 @@ type claudeAttribution struct {
 	PR     string `json:"pr"`
 }
 
+func (c *AttributionCheck) Name() string { return "attribution" }
+
 func (c *AttributionCheck) Check(repo *Repo) []Result {
 	var results []Result
 
@@ -47,7 +51,10 @@ func (c *AttributionCheck) Check(repo *Repo) []Result {
 				})
 			}
 		} else {
-			results = append(results, c.checkAttribution(data)...)
+			results = append(results, c.checkAttribution(data, repo.Config.Attribution)...)
+		}
+		if repo.Config.Attribution.ExpectedCommit == "" {
+			results = append(results, c.checkTrailers(repo)...)
 		}
 	}
 
@@ -61,7 +68,7 @@ func (c *AttributionCheck) Check(repo *Repo) []Result {
 	return results
 }
 
-func (c *AttributionCheck) checkAttribution(data []byte) []Result {
+func (c *AttributionCheck) checkAttribution(data []byte, want AttributionConfig) []Result {
 	var settings map[string]json.RawMessage
 	if err := json.Unmarshal(data, &settings); err != nil {
 		return []Result{{
@@ -90,15 +97,27 @@ func (c *AttributionCheck) checkAttribution(data []byte) []Result {
 		}}
 	}
 
-	if attr.Commit != "" || attr.PR != "" {
+	if attr.Commit != want.ExpectedCommit || attr.PR != want.ExpectedPR {
+		msg := fmt.Sprintf("attribution not empty (commit=%q, pr=%q)", attr.Commit, attr.PR)
+		if want.ExpectedCommit != "" || want.ExpectedPR != "" {
+			msg = fmt.Sprintf("attribution is (commit=%q, pr=%q), want (commit=%q, pr=%q)",
+				attr.Commit, attr.PR, want.ExpectedCommit, want.ExpectedPR)
+		}
 		return []Result{{
 			Name:    "claude/attribution",
 			Status:  StatusFail,
-			Message: fmt.Sprintf("attribution not empty (commit=%q, pr=%q)", attr.Commit, attr.PR),
+			Message: msg,
 			Fixable: true,
 		}}
 	}
 
+	if want.ExpectedCommit != "" || want.ExpectedPR != "" {
+		return []Result{{
+			Name:    "claude/attribution",
+			Status:  StatusOK,
+			Message: "attribution matches config",
+		}}
+	}
 	return []Result{{
 		Name:    "claude/attribution",
 		Status:  StatusOK,
@@ -106,6 +125,59 @@ func (c *AttributionCheck) checkAttribution(data []byte) []Result {
 	}}
 }
 
+// trailerScanCommits caps how many unpushed commits checkTrailers reads, so
+// a repo without remotes doesn't scan its whole history.
+const trailerScanCommits = 100
+
+// attributionTrailer matches the commit message lines Claude adds when
+// commit attribution is not empty.
+var attributionTrailer = regexp.MustCompile(`(?i)^(co-authored-by:.*\b(claude|anthropic)\b|.*generated with \[?claude)`)
+
+// checkTrailers catches what an empty attribution setting prevents but
+// cannot undo: unpushed commits whose messages already carry a Claude
+// Co-Authored-By or "Generated with" line. Removing them means rewording
+// the commits, so it only warns, one result per commit.
+func (c *AttributionCheck) checkTrailers(repo *Repo) []Result {
+	rev := "--branches"
+	if repo.Ref != "" {
+		rev = repo.Ref
+	}
+	out, err := repo.Git("log", "--max-count="+strconv.Itoa(trailerScanCommits), "--format=%h%x00%s%x00%B%x1e", rev, "--not", "--remotes")
+	if err != nil {
+		return nil
+	}
+
+	var results []Result
+	for _, record := range strings.Split(out, "\x1e") {
+		f := strings.SplitN(strings.TrimLeft(record, "\n"), "\x00", 3)
+		if len(f) != 3 {
+			continue
+		}
+		var lines []string
+		for _, line := range strings.Split(f[2], "\n") {
+			if line = strings.TrimSpace(line); attributionTrailer.MatchString(line) {
+				lines = append(lines, line)
+			}
+		}
+		if len(lines) > 0 {
+			results = append(results, Result{
+				Name:    fmt.Sprintf("claude/trailer[%s]", f[0]),
+				Status:  StatusWarn,
+				Message: fmt.Sprintf("commit %q has an AI attribution trailer", f[1]),
+				Details: lines,
+			})
+		}
+	}
+	if len(results) == 0 {
+		return []Result{{
+			Name:    "claude/trailer",
+			Status:  StatusOK,
+			Message: "no AI attribution trailers in unpushed commits",
+		}}
+	}
+	return results
+}
+
 // Patterns that should be in .git/info/exclude for shared repos.
 var localExcludes = []string{"CLAUDE.md", "AGENTS.md", ".claude/", ".reviews/"}
 
@@ -121,10 +193,19 @@ func (c *AttributionCheck) checkExclude(repo *Repo) []Result {
 	}
 
 	if len(missing) > 0 {
+		// Local excludes don't survive a reclone, so say why they are gone:
+		// applied before in this clone and since removed, or a fresh clone
+		// that still has git's template exclude file.
+		msg := fmt.Sprintf(".git/info/exclude missing: %s", strings.Join(missing, ", "))
+		if applied, _ := repo.Git("config", "--type=bool", "--get", excludesAppliedKey); applied == "true" {
+			msg = fmt.Sprintf("local excludes were applied but have been reset; missing: %s", strings.Join(missing, ", "))
+		} else if isTemplateExclude(existing) {
+			msg = fmt.Sprintf("local excludes never configured in this clone (exclude file is the default template); missing: %s", strings.Join(missing, ", "))
+		}
 		return []Result{{
 			Name:    "local/exclude",
 			Status:  StatusFail,
-			Message: fmt.Sprintf(".git/info/exclude missing: %s", strings.Join(missing, ", ")),
+			Message: msg,
 			Fixable: true,
 		}}
 	}
@@ -146,20 +227,26 @@ func (c *AttributionCheck) Fix(repo *Repo, results []Result) []Result {
 		switch {
 		case r.Name == "claude/attribution":
 			path := filepath.Join(repo.Dir, settingsRelPath)
-			if err := ensureAttribution(path); err != nil {
+			want := repo.Config.Attribution
+			if err := ensureAttribution(repo, path, want); err != nil {
 				fixed = append(fixed, r)
 			} else {
+				msg := fmt.Sprintf("set empty attribution in %s", settingsRelPath)
+				if want.ExpectedCommit != "" || want.ExpectedPR != "" {
+					msg = fmt.Sprintf("set configured attribution in %s", settingsRelPath)
+				}
 				fixed = append(fixed, Result{
 					Name:    r.Name,
 					Status:  StatusFix,
-					Message: fmt.Sprintf("set empty attribution in %s", settingsRelPath),
+					Message: msg,
 				})
 			}
 		case r.Name == "local/exclude":
 			excludePath := filepath.Join(repo.Dir, ".git", "info", "exclude")
-			if err := ensureExcludePatterns(excludePath); err != nil {
+			if err := ensureExcludePatterns(repo, excludePath); err != nil {
 				fixed = append(fixed, r)
 			} else {
+				_ = repo.SetGitConfig(excludesAppliedKey, "true")
 				fixed = append(fixed, Result{
 					Name:    r.Name,
 					Status:  StatusFix,
@@ -173,44 +260,42 @@ func (c *AttributionCheck) Fix(repo *Repo, results []Result) []Result {
 	return fixed
 }
 
-// ensureExcludePatterns appends missing patterns to the exclude file.
-func ensureExcludePatterns(path string) error {
-	existing := readLines(path)
+// excludesAppliedKey records in .git/config that the local excludes were
+// applied, so a later check can tell a reset exclude file from one that was
+// never set up.
+const excludesAppliedKey = "lint.excludesApplied"
 
-	var toAdd []string
-	for _, pattern := range localExcludes {
-		if !containsLine(existing, pattern) {
-			toAdd = append(toAdd, pattern)
+// isTemplateExclude reports whether an exclude file's lines are empty or
+// only comments, as in the template git writes on clone.
+func isTemplateExclude(lines []string) bool {
+	for _, line := range lines {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+			return false
 		}
 	}
-	if len(toAdd) == 0 {
-		return nil
-	}
+	return true
+}
 
-	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
-		return err
-	}
-	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
-	if err != nil {
-		return err
-	}
-	defer f.Close()
+// ensureExcludePatterns appends missing patterns to the exclude file.
+func ensureExcludePatterns(repo *Repo, path string) error {
+	existing := readLines(path)
 
+	var b strings.Builder
 	// Ensure we start on a new line if the file doesn't end with one.
-	if len(existing) > 0 {
-		last := existing[len(existing)-1]
-		if last != "" {
-			if _, err := f.WriteString("\n"); err != nil {
-				return err
-			}
-		}
+	if len(existing) > 0 && existing[len(existing)-1] != "" {
+		b.WriteString("\n")
 	}
-	for _, pattern := range toAdd {
-		if _, err := fmt.Fprintln(f, pattern); err != nil {
-			return err
+	added := false
+	for _, pattern := range localExcludes {
+		if !containsLine(existing, pattern) {
+			b.WriteString(pattern + "\n")
+			added = true
 		}
 	}
-	return nil
+	if !added {
+		return nil
+	}
+	return repo.AppendFile(path, []byte(b.String()))
 }
 
 // readLines returns all lines from a file, or nil if unreadable.
@@ -238,8 +323,9 @@ func containsLine(lines []string, target string) bool {
 	return false
 }
 
-// ensureAttribution reads (or creates) the settings file and sets empty attribution.
-func ensureAttribution(path string) error {
+// ensureAttribution reads (or creates) the settings file and sets attribution
+// to the configured values, which are empty by default.
+func ensureAttribution(repo *Repo, path string, want AttributionConfig) error {
 	var settings map[string]json.RawMessage
 
 	data, err := os.ReadFile(path)
@@ -253,7 +339,7 @@ func ensureAttribution(path string) error {
 		return err
 	}
 
-	attr := claudeAttribution{Commit: "", PR: ""}
+	attr := claudeAttribution{Commit: want.ExpectedCommit, PR: want.ExpectedPR}
 	raw, err := json.Marshal(attr)
 	if err != nil {
 		return err
@@ -264,10 +350,5 @@ func ensureAttribution(path string) error {
 	if err != nil {
 		return err
 	}
-	out = append(out, '\n')
-
-	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
-		return err
-	}
-	return os.WriteFile(path, out, 0o644)
+	return repo.WriteFile(path, append(out, '\n'))
 }
@@ -3,7 +3,9 @@ package main
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestAttributionPersonalRepoNoResults(t *testing.T) {
@@ -44,3 +46,97 @@ func TestAttributionWorkRepoFixCreatesSettingsAndExcludes(t *testing.T) {
 		t.Errorf("settings file not created: %v", err)
 	}
 }
+
+func TestAttributionExpectedValues(t *testing.T) {
+	want := AttributionConfig{ExpectedCommit: "Co-authored by AI", ExpectedPR: ""}
+
+	// Empty attribution fails when the config expects a value.
+	results := (&AttributionCheck{}).checkAttribution([]byte(`{"attribution":{"commit":"","pr":""}}`), want)
+	if results[0].Status != StatusFail || !results[0].Fixable {
+		t.Errorf("empty attribution with expectation = %+v, want fixable fail", results[0])
+	}
+
+	// Matching values pass.
+	results = (&AttributionCheck{}).checkAttribution([]byte(`{"attribution":{"commit":"Co-authored by AI","pr":""}}`), want)
+	if results[0].Status != StatusOK {
+		t.Errorf("matching attribution = %+v, want ok", results[0])
+	}
+
+	// The fix writes the configured values.
+	path := filepath.Join(t.TempDir(), "settings.local.json")
+	if err := ensureAttribution(&Repo{}, path, want); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := (&AttributionCheck{}).checkAttribution(data, want); got[0].Status != StatusOK {
+		t.Errorf("after fix = %+v, want ok", got[0])
+	}
+}
+
+func TestAttributionExcludeResetMessage(t *testing.T) {
+	r := newTestRepo(t)
+	r.git("remote", "add", "origin", "git@github.com:acme/repo.git")
+	r.Config.WorkOrgs = []string{"acme"}
+	r.reload()
+
+	excludePath := filepath.Join(r.dir, ".git", "info", "exclude")
+	template := []byte("# git ls-files --others --exclude-from=.git/info/exclude\n# Lines that start with '#' are comments.\n")
+	if err := os.WriteFile(excludePath, template, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	results := (&AttributionCheck{}).Check(r.Repo)
+	got, _ := resultByName(results, "local/exclude")
+	if !strings.Contains(got.Message, "never configured") {
+		t.Errorf("fresh clone message = %q, want never configured", got.Message)
+	}
+
+	(&AttributionCheck{}).Fix(r.Repo, results)
+	if err := os.WriteFile(excludePath, template, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	got, _ = resultByName((&AttributionCheck{}).Check(r.Repo), "local/exclude")
+	if !strings.Contains(got.Message, "have been reset") {
+		t.Errorf("after reset message = %q, want have been reset", got.Message)
+	}
+}
+
+func TestAttributionTrailers(t *testing.T) {
+	r := newTestRepo(t)
+	r.git("remote", "add", "origin", "git@github.com:acme/repo.git")
+	r.Config.WorkOrgs = []string{"acme"}
+	r.reload()
+	r.commit("a.txt", "a", "Add a", time.Now())
+	if got, _ := resultByName((&AttributionCheck{}).Check(r.Repo), "claude/trailer"); got.Status != StatusOK {
+		t.Errorf("clean commits = %+v, want ok", got)
+	}
+
+	r.commit("b.txt", "b", "Add b\n\nGenerated with [Claude Code](https://claude.com/claude-code)\n\nCo-Authored-By: Claude <noreply@anthropic.com>", time.Now())
+	short := r.git("rev-parse", "--short", "HEAD")
+	results := (&AttributionCheck{}).Check(r.Repo)
+	got, ok := resultByName(results, "claude/trailer["+short+"]")
+	if !ok || got.Status != StatusWarn || got.Fixable {
+		t.Fatalf("trailer commit = %+v, want non-fixable warn for %s", results, short)
+	}
+	if len(got.Details) != 2 {
+		t.Errorf("details = %q, want both trailer lines", got.Details)
+	}
+
+	// Pushed commits are history the check leaves alone.
+	r.git("update-ref", "refs/remotes/origin/main", "HEAD")
+	if got, _ := resultByName((&AttributionCheck{}).Check(r.Repo), "claude/trailer"); got.Status != StatusOK {
+		t.Errorf("after push = %+v, want ok", got)
+	}
+
+	// A configured commit attribution makes trailers expected.
+	r.git("update-ref", "-d", "refs/remotes/origin/main")
+	r.Config.Attribution.ExpectedCommit = "Co-Authored-By: Claude <noreply@anthropic.com>"
+	for _, res := range (&AttributionCheck{}).Check(r.Repo) {
+		if strings.HasPrefix(res.Name, "claude/trailer") {
+			t.Errorf("with expectedCommit: got %+v", res)
+		}
+	}
+}
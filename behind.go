@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// BehindCheck warns when the remote default branch is far ahead of local
+// main, e.g. after weeks away. A large gap raises the risk of painful
+// rebases, so it deserves a heads-up of its own. The check is opt-in: it
+// only runs when thresholds.behindMaxCommits is set.
+type BehindCheck struct{}
+
+func (c *BehindCheck) Name() string { return "behind" }
+
+func (c *BehindCheck) Check(repo *Repo) []Result {
+	maxBehind := repo.Config.Thresholds.BehindMaxCommits
+	if maxBehind == 0 {
+		return nil
+	}
+	mainBranch := repo.MainBranch()
+	if mainBranch == "" {
+		return nil
+	}
+	remoteRef := mainRemoteRef(repo, mainBranch)
+	if remoteRef == "" {
+		return nil
+	}
+
+	out, err := repo.Git("rev-list", "--count", mainBranch+".."+remoteRef)
+	if err != nil {
+		return nil
+	}
+	behind, err := strconv.Atoi(out)
+	if err != nil {
+		return nil
+	}
+	if behind <= maxBehind {
+		return []Result{{
+			Name:    "staleness/behind",
+			Status:  StatusOK,
+			Message: fmt.Sprintf("%s is %d commits behind %s", mainBranch, behind, remoteRef),
+		}}
+	}
+
+	msg := fmt.Sprintf("%s is %d commits behind %s (max %d)", mainBranch, behind, remoteRef, maxBehind)
+	if date, err := repo.Git("log", "-1", "--format=%ci", remoteRef); err == nil {
+		if t, err := time.Parse("2006-01-02 15:04:05 -0700", date); err == nil {
+			msg += fmt.Sprintf("; newest upstream commit %s", t.Format("2006-01-02"))
+		}
+	}
+	return []Result{{
+		Name:    "staleness/behind",
+		Status:  StatusWarn,
+		Message: msg,
+	}}
+}
+
+func (c *BehindCheck) Fix(_ *Repo, results []Result) []Result {
+	return results
+}
+
+// mainRemoteRef returns the remote-tracking ref that local main follows:
+// its configured upstream, or origin/<main> if none is set. Returns "" when
+// neither ref exists.
+func mainRemoteRef(repo *Repo, mainBranch string) string {
+	if ref, err := repo.Git("rev-parse", "--abbrev-ref", mainBranch+"@{upstream}"); err == nil && ref != "" {
+		return ref
+	}
+	ref := "origin/" + mainBranch
+	if _, err := repo.Git("rev-parse", "--verify", "--quiet", "refs/remotes/"+ref); err != nil {
+		return ""
+	}
+	return ref
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBehindCheck(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("a.txt", "a", "first", time.Now())
+	base := r.git("rev-parse", "HEAD")
+	r.commit("b.txt", "b", "second", time.Now())
+	r.commit("c.txt", "c", "third", time.Now())
+	r.git("remote", "add", "origin", "https://github.com/me/repo.git")
+	r.git("update-ref", "refs/remotes/origin/main", "HEAD")
+	r.git("reset", "--hard", base)
+
+	if results := (&BehindCheck{}).Check(r.Repo); len(results) != 0 {
+		t.Errorf("threshold unset: got %+v, want none", results)
+	}
+
+	r.Config.Thresholds.BehindMaxCommits = 1
+	got, ok := resultByName((&BehindCheck{}).Check(r.Repo), "staleness/behind")
+	if !ok || got.Status != StatusWarn {
+		t.Errorf("staleness/behind = %+v, want warn", got)
+	}
+
+	r.Config.Thresholds.BehindMaxCommits = 5
+	if got, _ := resultByName((&BehindCheck{}).Check(r.Repo), "staleness/behind"); got.Status != StatusOK {
+		t.Errorf("within threshold = %+v, want ok", got)
+	}
+}
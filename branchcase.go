@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BranchCaseCheck warns when a local branch name differs from a
+// remote-tracking branch only by case. On case-insensitive filesystems the
+// two refs share a loose ref file, so fetch and push act on the wrong one.
+type BranchCaseCheck struct{}
+
+func (c *BranchCaseCheck) Name() string { return "branch-case" }
+
+func (c *BranchCaseCheck) Check(repo *Repo) []Result {
+	branches, err := localBranches(repo)
+	if err != nil || len(branches) == 0 {
+		return nil
+	}
+	remotes, _ := repo.Remotes()
+	if len(remotes) == 0 {
+		return nil
+	}
+	out, err := repo.Git("for-each-ref", "--format=%(refname)", "refs/remotes/")
+	if err != nil {
+		return nil
+	}
+
+	var results []Result
+	for _, ref := range strings.Split(out, "\n") {
+		remote, branch := splitRemoteRef(strings.TrimPrefix(ref, "refs/remotes/"), remotes)
+		if branch == "" || branch == "HEAD" {
+			continue
+		}
+		for _, local := range branches {
+			if local != branch && strings.EqualFold(local, branch) {
+				results = append(results, Result{
+					Name:    fmt.Sprintf("ref/case[%s]", local),
+					Status:  StatusWarn,
+					Message: fmt.Sprintf("differs only by case from %s/%s", remote, branch),
+				})
+			}
+		}
+	}
+
+	if len(results) == 0 {
+		return []Result{{
+			Name:    "ref/case",
+			Status:  StatusOK,
+			Message: "no case-only branch name clashes",
+		}}
+	}
+	return results
+}
+
+func (c *BranchCaseCheck) Fix(_ *Repo, results []Result) []Result {
+	return results
+}
+
+// splitRemoteRef splits "origin/feature/x" into the remote name and branch,
+// matching against known remote names since both may contain slashes.
+// Returns "", "" when no remote matches.
+func splitRemoteRef(short string, remotes []string) (remote, branch string) {
+	for _, name := range remotes {
+		if rest, ok := strings.CutPrefix(short, name+"/"); ok && len(name) > len(remote) {
+			remote, branch = name, rest
+		}
+	}
+	return remote, branch
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSplitRemoteRef(t *testing.T) {
+	remotes := []string{"origin", "origin/mirror"}
+	tests := []struct {
+		in, wantRemote, wantBranch string
+	}{
+		{"origin/main", "origin", "main"},
+		{"origin/feature/x", "origin", "feature/x"},
+		{"origin/mirror/dev", "origin/mirror", "dev"},
+		{"other/main", "", ""},
+	}
+	for _, tt := range tests {
+		remote, branch := splitRemoteRef(tt.in, remotes)
+		if remote != tt.wantRemote || branch != tt.wantBranch {
+			t.Errorf("splitRemoteRef(%q) = (%q, %q), want (%q, %q)",
+				tt.in, remote, branch, tt.wantRemote, tt.wantBranch)
+		}
+	}
+}
+
+func TestBranchCaseClash(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("a.txt", "a", "first", time.Now())
+	r.git("remote", "add", "origin", "https://github.com/me/repo.git")
+	r.git("update-ref", "refs/remotes/origin/feature", "HEAD")
+	r.git("update-ref", "refs/remotes/origin/main", "HEAD")
+	r.git("branch", "Feature")
+
+	results := (&BranchCaseCheck{}).Check(r.Repo)
+	got, ok := resultByName(results, "ref/case[Feature]")
+	if !ok || got.Status != StatusWarn {
+		t.Fatalf("ref/case[Feature] = %+v, want warn; got %+v", got, results)
+	}
+	if _, ok := resultByName(results, "ref/case[main]"); ok {
+		t.Error("exact-match branch main should not be flagged")
+	}
+}
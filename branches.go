@@ -1,12 +1,18 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 )
 
 type BranchCleanupCheck struct{}
 
+func (c *BranchCleanupCheck) Name() string { return "branch-cleanup" }
+
 func (c *BranchCleanupCheck) Check(repo *Repo) []Result {
 	mainBranch := repo.MainBranch()
 
@@ -20,6 +26,14 @@ func (c *BranchCleanupCheck) Check(repo *Repo) []Result {
 	merged := mergedBranches(repo, mainBranch)
 
 	var results []Result
+	generic, err := genericBranchRegexp(repo.Config.GenericBranchPattern)
+	if err != nil {
+		results = append(results, Result{
+			Name:    "branch/generic-pattern",
+			Status:  StatusWarn,
+			Message: fmt.Sprintf("invalid genericBranchPattern: %v", err),
+		})
+	}
 	for _, line := range strings.Split(out, "\n") {
 		parts := strings.SplitN(line, "|", 6)
 		if len(parts) < 6 {
@@ -77,6 +91,25 @@ func (c *BranchCleanupCheck) Check(repo *Repo) []Result {
 					unsafeReason = fmt.Sprintf(" (no merged PR on %s; use git branch -D to discard)", trackedRemote)
 				}
 			}
+		} else if upstream == "" && repo.Config.Thresholds.AbandonedMaxAge.Duration > 0 {
+			if reason := abandonedBranch(repo, name, mainBranch, repo.Config.Thresholds.AbandonedMaxAge.Duration); reason != "" {
+				r = &Result{
+					Name:    fmt.Sprintf("branch/abandoned[%s]", name),
+					Message: fmt.Sprintf("%s (%s)", reason, hash),
+				}
+				safe = false
+				unsafeReason = " (never pushed; push it or use git branch -D to discard)"
+			}
+		}
+		if r == nil && generic != nil && generic.MatchString(name) {
+			// Throwaway names like GitHub's web-editor "patch-1". Merged
+			// ones are already caught above; the rest may hold real work.
+			r = &Result{
+				Name:    fmt.Sprintf("branch/generic[%s]", name),
+				Message: fmt.Sprintf("generic name, likely throwaway (%s by %s)", hash, author),
+			}
+			safe = false
+			unsafeReason = " (not merged; use git branch -D to discard)"
 		}
 		if r == nil {
 			continue
@@ -94,7 +127,7 @@ func (c *BranchCleanupCheck) Check(repo *Repo) []Result {
 			fixable = true
 		case sameDir(worktree, repo.Dir):
 			notFixableReason = " (checked out, switch branch to fix)"
-		case worktreeClean(worktree):
+		case worktreeClean(repo.Context(), worktree):
 			fixable = true
 		default:
 			notFixableReason = fmt.Sprintf(" (checked out at %s, uncommitted changes)", worktree)
@@ -154,10 +187,23 @@ func (c *BranchCleanupCheck) Fix(repo *Repo, results []Result) []Result {
 	return fixed
 }
 
+// defaultGenericBranchPattern matches the branch names GitHub's web editor
+// creates.
+const defaultGenericBranchPattern = `^patch-\d+$`
+
+// genericBranchRegexp compiles the genericBranchPattern config value, using
+// the default when it is empty.
+func genericBranchRegexp(pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		pattern = defaultGenericBranchPattern
+	}
+	return regexp.Compile(pattern)
+}
+
 // worktreeClean reports whether the worktree at path has no uncommitted
 // or untracked changes.
-func worktreeClean(path string) bool {
-	out, err := gitInDir(path, "status", "--porcelain")
+func worktreeClean(ctx context.Context, path string) bool {
+	out, err := gitInDir(ctx, path, "status", "--porcelain")
 	if err != nil {
 		return false
 	}
@@ -194,7 +240,7 @@ func nonOriginBranchSafe(repo *Repo, branch, remote string) bool {
 	if err != nil {
 		return false
 	}
-	inMerged, _ := ghCommitInMergedPR(owner, repoName, sha)
+	inMerged, _ := ghCommitInMergedPR(repo.Context(), owner, repoName, sha)
 	return inMerged
 }
 
@@ -224,10 +270,36 @@ func goneBranchSafe(repo *Repo, branch, mainBranch string) bool {
 	if err != nil {
 		return false
 	}
-	inMerged, _ := ghCommitInMergedPR(owner, repoName, sha)
+	inMerged, _ := ghCommitInMergedPR(repo.Context(), owner, repoName, sha)
 	return inMerged
 }
 
+// abandonedBranch returns a non-empty reason if branch has commits that are
+// not on main and its newest commit is older than maxAge: local work that
+// was never pushed and has since been forgotten.
+func abandonedBranch(repo *Repo, branch, mainBranch string, maxAge time.Duration) string {
+	if mainBranch == "" {
+		return ""
+	}
+	count, err := repo.Git("rev-list", "--count", mainBranch+".."+branch)
+	if err != nil || count == "0" {
+		return ""
+	}
+	stamp, err := repo.Git("log", "-1", "--format=%ct", branch)
+	if err != nil {
+		return ""
+	}
+	secs, err := strconv.ParseInt(stamp, 10, 64)
+	if err != nil {
+		return ""
+	}
+	age := time.Since(time.Unix(secs, 0))
+	if age <= maxAge {
+		return ""
+	}
+	return fmt.Sprintf("no upstream, %s commits ahead of %s, newest %s old", count, mainBranch, formatDuration(age))
+}
+
 // stalePRCheckout returns a non-empty reason if branch tracks a refs/pull/
 // ref and is stale: the branch is merged into main, the local commit no
 // longer matches the remote PR head, or the PR is merged or closed on GitHub.
@@ -272,7 +344,7 @@ func stalePRCheckout(repo *Repo, branch, shortHash, author, mainBranch string) s
 	// main and the PR ref still matches the local tip.
 	owner, repoName := parseGitHubRepo(repo.RemoteURL(remote))
 	if owner != "" {
-		switch state, _ := ghPRState(owner, repoName, pr); state {
+		switch state, _ := ghPRState(repo.Context(), owner, repoName, pr); state {
 		case "merged":
 			return fmt.Sprintf("PR #%s merged %s", pr, detail)
 		case "closed":
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"strings"
 	"testing"
 	"time"
 )
@@ -74,3 +75,56 @@ func TestBranchCleanupOrphanForeignAuthor(t *testing.T) {
 		t.Fatalf("orphan branch = %+v, want fixable warn", results)
 	}
 }
+
+func TestBranchCleanupGenericName(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("a.txt", "a", "first", time.Now())
+	r.git("branch", "patch-1") // merged: cleaned up as branch/merged
+	r.git("checkout", "-b", "patch-2")
+	r.commit("b.txt", "b", "web edit", time.Now())
+	r.git("checkout", "main")
+
+	results := (&BranchCleanupCheck{}).Check(r.Repo)
+	if got, ok := resultByName(results, "branch/merged[patch-1]"); !ok || !got.Fixable {
+		t.Errorf("merged patch-1 = %+v, want fixable branch/merged", got)
+	}
+	got, ok := resultByName(results, "branch/generic[patch-2]")
+	if !ok || got.Status != StatusWarn || got.Fixable {
+		t.Errorf("unmerged patch-2 = %+v, want non-fixable warn", got)
+	}
+
+	// A custom pattern replaces the default.
+	r.Config.GenericBranchPattern = `^tmp-`
+	if _, ok := resultByName((&BranchCleanupCheck{}).Check(r.Repo), "branch/generic[patch-2]"); ok {
+		t.Error("patch-2 flagged with a custom pattern that does not match it")
+	}
+}
+
+func TestBranchCleanupAbandoned(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("a.txt", "a", "first", time.Now())
+	r.git("checkout", "-b", "old-idea")
+	r.commit("b.txt", "b", "started something", time.Now().Add(-60*24*time.Hour))
+	r.commit("c.txt", "c", "kept going", time.Now().Add(-45*24*time.Hour))
+	r.git("checkout", "-b", "new-idea", "main")
+	r.commit("d.txt", "d", "fresh work", time.Now())
+	r.git("checkout", "main")
+
+	// Disabled by default.
+	if _, ok := resultByName((&BranchCleanupCheck{}).Check(r.Repo), "branch/abandoned[old-idea]"); ok {
+		t.Error("abandoned branch flagged without abandonedMaxAge")
+	}
+
+	r.Config.Thresholds.AbandonedMaxAge = Duration{30 * 24 * time.Hour}
+	results := (&BranchCleanupCheck{}).Check(r.Repo)
+	got, ok := resultByName(results, "branch/abandoned[old-idea]")
+	if !ok || got.Status != StatusWarn || got.Fixable {
+		t.Fatalf("old-idea = %+v, want non-fixable warn", results)
+	}
+	if !strings.Contains(got.Message, "2 commits ahead of main, newest 45d old") {
+		t.Errorf("message = %q, want commit count and age", got.Message)
+	}
+	if _, ok := resultByName(results, "branch/abandoned[new-idea]"); ok {
+		t.Error("recent branch flagged as abandoned")
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// BranchNamingCheck enforces a team branch naming convention: every local
+// branch except the main branch and the current one must match the
+// branchPattern regexp, e.g. ^(feat|fix|chore)/. The check does nothing
+// when no pattern is configured. Renaming is left to the user, since the
+// branch may already be pushed or referenced by a PR.
+type BranchNamingCheck struct{}
+
+func (c *BranchNamingCheck) Name() string { return "branch-naming" }
+
+func (c *BranchNamingCheck) Check(repo *Repo) []Result {
+	if repo.Config.BranchPattern == "" {
+		return nil
+	}
+	pattern, err := regexp.Compile(repo.Config.BranchPattern)
+	if err != nil {
+		return []Result{{
+			Name:    "branch/naming",
+			Status:  StatusWarn,
+			Message: fmt.Sprintf("invalid branchPattern: %v", err),
+		}}
+	}
+
+	out, err := repo.Git("for-each-ref", "--format=%(refname:short)", "refs/heads/")
+	if err != nil || out == "" {
+		return nil
+	}
+	mainBranch := repo.MainBranch()
+	current, _ := repo.Git("symbolic-ref", "--quiet", "--short", "HEAD")
+
+	var results []Result
+	for _, name := range strings.Split(out, "\n") {
+		if name == mainBranch || name == current || pattern.MatchString(name) {
+			continue
+		}
+		results = append(results, Result{
+			Name:    fmt.Sprintf("branch/naming[%s]", name),
+			Status:  StatusWarn,
+			Message: fmt.Sprintf("name does not match %s", repo.Config.BranchPattern),
+		})
+	}
+	if len(results) == 0 {
+		return []Result{{
+			Name:    "branch/naming",
+			Status:  StatusOK,
+			Message: fmt.Sprintf("branch names match %s", repo.Config.BranchPattern),
+		}}
+	}
+	return results
+}
+
+func (c *BranchNamingCheck) Fix(_ *Repo, results []Result) []Result {
+	return results
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBranchNamingCheck(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("a.txt", "a", "first", time.Now())
+	r.git("branch", "feat/login")
+	r.git("branch", "wip")
+	r.git("checkout", "-q", "-b", "scratch")
+
+	if results := (&BranchNamingCheck{}).Check(r.Repo); results != nil {
+		t.Errorf("branchPattern unset: got %+v, want nil", results)
+	}
+
+	r.Config.BranchPattern = `^(feat|fix|chore)/`
+	results := (&BranchNamingCheck{}).Check(r.Repo)
+	if got, ok := resultByName(results, "branch/naming[wip]"); !ok || got.Status != StatusWarn {
+		t.Errorf("wip = %+v, want warn", results)
+	}
+	for _, name := range []string{"branch/naming[feat/login]", "branch/naming[main]", "branch/naming[scratch]"} {
+		if _, ok := resultByName(results, name); ok {
+			t.Errorf("%s flagged; matching, main, and current branches are exempt", name)
+		}
+	}
+
+	r.Config.BranchPattern = `(`
+	if got, _ := resultByName((&BranchNamingCheck{}).Check(r.Repo), "branch/naming"); got.Status != StatusWarn {
+		t.Errorf("invalid pattern = %+v, want warn", got)
+	}
+}
+
+func TestBranchNamingKeepsTrackingWarning(t *testing.T) {
+	results := []Result{
+		{Name: "branch/naming[wip]", Status: StatusWarn},
+		{Name: "remote/branch-tracking[wip]", Status: StatusWarn},
+	}
+	if got := suppressRedundantTracking(results); len(got) != 2 {
+		t.Errorf("suppressRedundantTracking = %+v; a naming warning is not a cleanup flag", got)
+	}
+}
@@ -1,5 +1,7 @@
 package main
 
+import "time"
+
 const (
 	StatusOK   = "ok"
 	StatusWarn = "warn"
@@ -8,14 +10,35 @@ const (
 )
 
 type Result struct {
-	Name    string // e.g. "identity/email"
-	Status  string // "ok", "warn", "fail", "fix"
-	Message string
-	Details []string // per-item detail lines (filenames, commits, etc.)
-	Fixable bool
+	Name    string   `json:"name"`   // e.g. "identity/email"
+	Status  string   `json:"status"` // "ok", "warn", "fail", "fix"
+	Message string   `json:"message"`
+	Details []string `json:"details,omitempty"` // per-item detail lines (filenames, commits, etc.)
+	Fixable bool     `json:"fixable,omitempty"`
+
+	// Check is the Name of the check that reported this result; runChecks
+	// sets it.
+	Check string `json:"-"`
+
+	// FirstSeen is when this result was first reported for the repo. It is
+	// only set when the state file is enabled.
+	FirstSeen *time.Time `json:"first_seen,omitempty"`
+}
+
+// refNotApplicable is the result a working-tree-only check returns under
+// --ref.
+func refNotApplicable(name string) []Result {
+	return []Result{{
+		Name:    name,
+		Status:  StatusOK,
+		Message: "n/a for --ref",
+	}}
 }
 
 type Check interface {
+	// Name is the stable identifier the checks config uses to turn the
+	// check off, e.g. "attribution".
+	Name() string
 	Check(repo *Repo) []Result
 	Fix(repo *Repo, results []Result) []Result
 }
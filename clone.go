@@ -1,31 +1,63 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
 )
 
-// ghUser returns the authenticated GitHub user login.
-func ghUser() (string, error) {
-	cmd := exec.Command("gh", "api", "user", "--jq", ".login")
-	out, err := cmd.Output()
+// ghUserCache holds the authenticated login for the process lifetime, so
+// checks across many repos in one run spawn `gh api user` only once. Lookup
+// errors are cached too: without gh, every later call would fail the same way.
+var ghUserCache struct {
+	sync.Mutex
+	done  bool
+	login string
+	err   error
+}
+
+// ghUserLookup queries gh for the login; tests replace it to avoid gh.
+var ghUserLookup = func() (string, error) {
+	out, err := runCommand(context.Background(), "", "gh", "api", "user", "--jq", ".login")
 	if err != nil {
 		return "", fmt.Errorf("gh api user: %w (is gh installed and authenticated?)", err)
 	}
-	login := strings.TrimSpace(string(out))
+	login := strings.TrimSpace(out)
 	if login == "" {
 		return "", fmt.Errorf("gh api user returned empty login")
 	}
 	return login, nil
 }
 
+// ghUser returns the authenticated GitHub user login.
+func ghUser() (string, error) {
+	ghUserCache.Lock()
+	defer ghUserCache.Unlock()
+	if !ghUserCache.done {
+		ghUserCache.login, ghUserCache.err = ghUserLookup()
+		ghUserCache.done = true
+	}
+	return ghUserCache.login, ghUserCache.err
+}
+
+// resetGHUserCache forgets the cached login, e.g. after `gh auth switch` or
+// between tests.
+func resetGHUserCache() {
+	ghUserCache.Lock()
+	defer ghUserCache.Unlock()
+	ghUserCache.done = false
+	ghUserCache.login, ghUserCache.err = "", nil
+}
+
 // ghHasFork checks whether user has a fork of owner/repo.
 // It queries user/repo and checks if its parent is owner/repo.
-func ghHasFork(user, owner, repo string) bool {
-	parent, ok := ghForkParent(user, repo)
+func ghHasFork(ctx context.Context, user, owner, repo string) bool {
+	parent, ok := ghForkParent(ctx, user, repo)
 	return ok && parent == owner+"/"+repo
 }
 
@@ -63,13 +95,13 @@ func cloneRepo(cfg *Config, arg string) error {
 		// I own this repo; clone it as origin.
 		cloneOwner, cloneRepo = owner, repo
 		// If it's a fork, add the parent as upstream.
-		if parent, ok := ghForkParent(owner, repo); ok && parent != "" {
+		if parent, ok := ghForkParent(context.Background(), owner, repo); ok && parent != "" {
 			parts := strings.SplitN(parent, "/", 2)
 			upstreamOwner, upstreamRepo = parts[0], parts[1]
 		}
 	} else {
 		// Someone else's repo. Check if I have a fork.
-		if ghHasFork(me, owner, repo) {
+		if ghHasFork(context.Background(), me, owner, repo) {
 			cloneOwner, cloneRepo = me, repo
 			upstreamOwner, upstreamRepo = owner, repo
 		} else {
@@ -77,23 +109,24 @@ func cloneRepo(cfg *Config, arg string) error {
 		}
 	}
 
+	// Clone and fetch are the network steps --timeout bounds; cloneGit
+	// reports a timeout in its error, since there is no repo to attach a
+	// remote/timeout result to yet.
+	ctx := context.Background()
 	cloneURL := githubCloneURL(cloneOwner, cloneRepo, protocol)
 	fmt.Printf("Cloning %s/%s ...\n", cloneOwner, cloneRepo)
-	cmd := exec.Command("git", "clone", cloneURL, dest)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("git clone: %w", err)
+	if err := cloneGit(ctx, "", "clone", cloneURL, dest); err != nil {
+		return err
 	}
 
 	if upstreamOwner != "" {
 		upstreamURL := githubCloneURL(upstreamOwner, upstreamRepo, protocol)
 		fmt.Printf("Adding upstream %s/%s ...\n", upstreamOwner, upstreamRepo)
-		cmd = exec.Command("git", "-C", dest, "remote", "add", "upstream", upstreamURL)
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
-		if err := cmd.Run(); err != nil {
-			return fmt.Errorf("git remote add upstream: %w", err)
+		if err := cloneGit(ctx, dest, "remote", "add", "upstream", upstreamURL); err != nil {
+			return err
+		}
+		if err := cloneGit(ctx, dest, "fetch", "upstream"); err != nil {
+			return err
 		}
 	}
 
@@ -102,6 +135,15 @@ func cloneRepo(cfg *Config, arg string) error {
 		return err
 	}
 
+	// RemoteCheck only wires up fork tracking in work repos; a clone of any
+	// fork gets it here, so personal forks of open-source projects can't
+	// push their main branch either.
+	if upstreamOwner != "" {
+		if err := guardCloneMainBranch(absDir, cfg); err != nil {
+			return err
+		}
+	}
+
 	fmt.Printf("\nLinting %s ...\n", dest)
 	opts := lintOptions{
 		cfg:     cfg,
@@ -111,3 +153,37 @@ func cloneRepo(cfg *Config, arg string) error {
 	lintRepo(absDir, opts)
 	return nil
 }
+
+// cloneGit runs a git command for cloneRepo in dir through runCommand, so
+// network steps are bounded by --timeout. Other errors include git's stderr.
+func cloneGit(ctx context.Context, dir string, args ...string) error {
+	_, err := runCommand(ctx, dir, "git", args...)
+	if err == nil || errors.Is(err, errTimedOut) {
+		return err
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		if msg := strings.TrimSpace(string(exitErr.Stderr)); msg != "" {
+			return fmt.Errorf("git %s: %w\n%s", args[0], err, msg)
+		}
+	}
+	return fmt.Errorf("git %s: %w", args[0], err)
+}
+
+// guardCloneMainBranch makes the main branch of the fork cloned into dir
+// track upstream with pushes disabled.
+func guardCloneMainBranch(dir string, cfg *Config) error {
+	repo, err := NewRepo(dir, cfg)
+	if err != nil {
+		return err
+	}
+	mainBranch := repo.MainBranch()
+	if mainBranch == "" {
+		return nil
+	}
+	if err := guardForkBranch(repo, mainBranch); err != nil {
+		return fmt.Errorf("configuring %s to track upstream: %w", mainBranch, err)
+	}
+	fmt.Printf("Set %s to track upstream with pushes disabled\n", mainBranch)
+	return nil
+}
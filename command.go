@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultCommandTimeout bounds each network lookup, so a hung remote cannot
+// freeze a recursive scan.
+const defaultCommandTimeout = 10 * time.Second
+
+// commandTimeout is the per-command limit for network lookups; main sets it
+// from --timeout. Zero disables it.
+var commandTimeout = defaultCommandTimeout
+
+// networkGitCommands are the git subcommands that talk to a remote. Local
+// commands such as status or log may be slow on a large repo, but they
+// finish, so they run without a limit.
+var networkGitCommands = map[string]bool{
+	"clone":     true,
+	"ls-remote": true,
+	"fetch":     true,
+	"pull":      true,
+	"push":      true,
+}
+
+// networkCommand reports whether name with args contacts a remote: every gh
+// call, and the git subcommands in networkGitCommands.
+func networkCommand(name string, args []string) bool {
+	switch name {
+	case "gh":
+		return true
+	case "git":
+		return len(args) > 0 && networkGitCommands[args[0]]
+	}
+	return false
+}
+
+// errTimedOut marks a command that was killed by commandTimeout.
+var errTimedOut = errors.New("timed out")
+
+// runCommand runs name with args in dir (the current directory when empty)
+// and returns its stdout without trailing newlines. The command is killed
+// when ctx is done, and a network command also after commandTimeout; a
+// timeout returns an error wrapping errTimedOut and is noted in ctx's timeout
+// log, if any.
+func runCommand(ctx context.Context, dir, name string, args ...string) (string, error) {
+	cmdCtx, cancel := ctx, context.CancelFunc(func() {})
+	if commandTimeout > 0 && networkCommand(name, args) {
+		cmdCtx, cancel = context.WithTimeout(ctx, commandTimeout)
+	}
+	defer cancel()
+	cmd := exec.CommandContext(cmdCtx, name, args...)
+	cmd.Dir = dir
+	// git hands the network to ssh or a credential helper, which may keep
+	// stdout open after git itself is killed.
+	cmd.WaitDelay = time.Second
+	out, err := cmd.Output()
+	if err != nil && errors.Is(cmdCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil {
+		line := name + " " + strings.Join(args, " ")
+		if log, ok := ctx.Value(timeoutLogKey{}).(*timeoutLog); ok {
+			log.add(line)
+		}
+		return "", fmt.Errorf("%s: %w after %s", line, errTimedOut, commandTimeout)
+	}
+	return strings.TrimRight(string(out), "\n"), err
+}
+
+// timeoutLog collects the commands that timed out while checking one repo.
+type timeoutLog struct {
+	mu       sync.Mutex
+	commands []string
+}
+
+type timeoutLogKey struct{}
+
+// withTimeoutLog returns a context whose commands note timeouts in log.
+func withTimeoutLog(ctx context.Context, log *timeoutLog) context.Context {
+	return context.WithValue(ctx, timeoutLogKey{}, log)
+}
+
+func (l *timeoutLog) add(command string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.commands = append(l.commands, command)
+}
+
+// results reports the logged timeouts as one warning: the checks that made
+// those lookups carried on as if the lookup had failed, so their results
+// may be incomplete.
+func (l *timeoutLog) results() []Result {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.commands) == 0 {
+		return nil
+	}
+	return []Result{{
+		Name:    "remote/timeout",
+		Status:  StatusWarn,
+		Message: fmt.Sprintf("remote lookup timed out after %s", commandTimeout),
+		Details: append([]string(nil), l.commands...),
+	}}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestRunCommandTimeout(t *testing.T) {
+	orig := commandTimeout
+	t.Cleanup(func() { commandTimeout = orig })
+
+	commandTimeout = 5 * time.Second
+	var log timeoutLog
+	ctx := withTimeoutLog(context.Background(), &log)
+	if out, err := runCommand(ctx, "", "sh", "-c", "echo hi"); err != nil || out != "hi" {
+		t.Errorf("runCommand = %q, %v; want hi", out, err)
+	}
+	if results := log.results(); results != nil {
+		t.Errorf("without timeout: got %+v, want none", results)
+	}
+
+	// git ls-remote against a server that accepts the connection but never
+	// answers; a local command is left alone.
+	commandTimeout = 50 * time.Millisecond
+	if _, err := runCommand(ctx, "", "sh", "-c", "sleep 0.2"); err != nil {
+		t.Errorf("local command error = %v, want no timeout", err)
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	url := "http://" + listener.Addr().String() + "/repo.git"
+	start := time.Now()
+	_, err = runCommand(ctx, t.TempDir(), "git", "ls-remote", url)
+	if !errors.Is(err, errTimedOut) {
+		t.Errorf("runCommand(git ls-remote) error = %v, want errTimedOut", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("runCommand(git ls-remote) took %s, want it killed", elapsed)
+	}
+	results := log.results()
+	if len(results) != 1 || results[0].Name != "remote/timeout" || results[0].Status != StatusWarn {
+		t.Fatalf("results = %+v, want one remote/timeout warning", results)
+	}
+	if got := results[0].Details; len(got) != 1 || got[0] != "git ls-remote "+url {
+		t.Errorf("details = %q, want [git ls-remote %s]", got, url)
+	}
+
+	// A command that fails on its own is not a timeout.
+	if _, err := runCommand(ctx, "", "false"); err == nil || errors.Is(err, errTimedOut) {
+		t.Errorf("runCommand(false) error = %v, want a plain failure", err)
+	}
+}
+
+func TestNetworkCommand(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want bool
+	}{
+		{"gh", []string{"api", "user"}, true},
+		{"git", []string{"ls-remote", "origin"}, true},
+		{"git", []string{"fetch", "upstream"}, true},
+		{"git", []string{"clone", "https://github.com/o/r.git"}, true},
+		{"git", []string{"status", "--porcelain"}, false},
+		{"git", []string{"log", "-1"}, false},
+		{"git", nil, false},
+		{"sleep", []string{"10"}, false},
+	}
+	for _, tt := range tests {
+		if got := networkCommand(tt.name, tt.args); got != tt.want {
+			t.Errorf("networkCommand(%q, %q) = %v, want %v", tt.name, tt.args, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// defaultCommitIdentityCommits is how many unpushed commits
+// CommitIdentityCheck scans when commitIdentityCommits is unset.
+const defaultCommitIdentityCommits = 100
+
+// CommitIdentityCheck looks at the unpushed commits of a work repo for ones
+// where the configured user is only one side: rebasing or amending a
+// colleague's commit makes the user its committer, and committing under the
+// user's name on someone else's behalf does the reverse. Either skews
+// attribution. CommitterCheck covers commits where both sides are the user.
+type CommitIdentityCheck struct{}
+
+func (c *CommitIdentityCheck) Name() string { return "commit-identity" }
+
+func (c *CommitIdentityCheck) Check(repo *Repo) []Result {
+	name := repo.Config.Identity.Name
+	accepted := acceptedEmails(repo)
+	if !repo.Work || (name == "" && len(accepted) == 0) {
+		return nil
+	}
+	n := repo.Config.Thresholds.CommitIdentityCommits
+	if n == 0 {
+		n = defaultCommitIdentityCommits
+	}
+	rev := "--branches"
+	if repo.Ref != "" {
+		rev = repo.Ref
+	}
+	out, err := repo.Git("log", "--max-count="+strconv.Itoa(n), "--format=%h%x00%an%x00%ae%x00%cn%x00%ce%x00%s", rev, "--not", "--remotes")
+	if err != nil || out == "" {
+		return nil
+	}
+
+	mine := func(n, email string) bool {
+		return (name != "" && n == name) || accepted[email]
+	}
+	var results []Result
+	for _, line := range strings.Split(out, "\n") {
+		f := strings.Split(line, "\x00")
+		if len(f) != 6 {
+			continue
+		}
+		hash, authorName, authorEmail, committerName, committerEmail, subject := f[0], f[1], f[2], f[3], f[4], f[5]
+		if mine(authorName, authorEmail) == mine(committerName, committerEmail) {
+			continue
+		}
+		results = append(results, Result{
+			Name:    fmt.Sprintf("commit/identity[%s]", hash),
+			Status:  StatusWarn,
+			Message: fmt.Sprintf("authored by %s <%s>, committed by %s <%s>: %s", authorName, authorEmail, committerName, committerEmail, subject),
+		})
+	}
+	if len(results) > 0 {
+		return results
+	}
+	return []Result{{
+		Name:    "commit/identity",
+		Status:  StatusOK,
+		Message: "no unpushed commits split between you and someone else",
+	}}
+}
+
+func (c *CommitIdentityCheck) Fix(_ *Repo, results []Result) []Result {
+	return results
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCommitIdentityCheck(t *testing.T) {
+	r := newTestRepo(t)
+	now := time.Now()
+	r.commit("a.txt", "a", "mine", now)
+
+	if results := (&CommitIdentityCheck{}).Check(r.Repo); len(results) != 0 {
+		t.Errorf("personal repo: got %+v, want none", results)
+	}
+
+	r.Work = true
+	r.Config.Identity.WorkEmail = "test@example.com"
+	if got, _ := resultByName((&CommitIdentityCheck{}).Check(r.Repo), "commit/identity"); got.Status != StatusOK {
+		t.Errorf("own commit = %+v, want ok", got)
+	}
+
+	// A colleague's commit the user rebased: authored by them, committed by
+	// the user.
+	r.commitAs("b.txt", "b", "colleague", "Other Dev", "other@example.com", now)
+	hash := r.git("rev-parse", "--short", "HEAD")
+	results := (&CommitIdentityCheck{}).Check(r.Repo)
+	got, ok := resultByName(results, "commit/identity["+hash+"]")
+	if !ok || got.Status != StatusWarn || !strings.Contains(got.Message, "other@example.com") {
+		t.Fatalf("commit/identity[%s] = %+v, want warn naming the author (all: %+v)", hash, got, results)
+	}
+	if len(results) != 1 {
+		t.Errorf("got %d results, want only the colleague's commit: %+v", len(results), results)
+	}
+
+	// The scan window stops before the colleague's commit.
+	r.commit("c.txt", "c", "mine again", now)
+	r.Config.Thresholds.CommitIdentityCommits = 1
+	if got, _ := resultByName((&CommitIdentityCheck{}).Check(r.Repo), "commit/identity"); got.Status != StatusOK {
+		t.Errorf("window of 1 = %+v, want ok", got)
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// commitMessageRules are the rule names commitMessages.rules accepts.
+var commitMessageRules = []string{"subject-length", "type", "trailing-period"}
+
+const defaultMaxSubjectLength = 72
+
+// defaultCommitTypes are the conventional-commit types accepted when
+// commitMessages.types is unset.
+var defaultCommitTypes = []string{"build", "chore", "ci", "docs", "feat", "fix", "perf", "refactor", "revert", "style", "test"}
+
+// conventionalSubject matches "type(scope)!: description", capturing the type.
+var conventionalSubject = regexp.MustCompile(`^([a-zA-Z]+)(\([^)]*\))?!?: \S`)
+
+// commitMessageProblems returns the rules subject breaks under cfg. Merge
+// commits and fixup!/squash!/amend! commits, which git writes or autosquash
+// folds away, are exempt.
+func commitMessageProblems(subject string, cfg CommitMessageConfig) []string {
+	for _, prefix := range []string{"Merge ", "fixup! ", "squash! ", "amend! "} {
+		if strings.HasPrefix(subject, prefix) {
+			return nil
+		}
+	}
+	enabled := func(rule string) bool {
+		return len(cfg.Rules) == 0 || slices.Contains(cfg.Rules, rule)
+	}
+
+	var problems []string
+	if enabled("subject-length") {
+		maxLen := cfg.MaxSubjectLength
+		if maxLen == 0 {
+			maxLen = defaultMaxSubjectLength
+		}
+		if n := utf8.RuneCountInString(subject); n > maxLen {
+			problems = append(problems, fmt.Sprintf("subject is %d characters (max %d)", n, maxLen))
+		}
+	}
+	if enabled("type") {
+		types := cfg.Types
+		if len(types) == 0 {
+			types = defaultCommitTypes
+		}
+		m := conventionalSubject.FindStringSubmatch(subject)
+		switch {
+		case m == nil:
+			problems = append(problems, "no type prefix (e.g. fix: ...)")
+		case !slices.Contains(types, m[1]):
+			problems = append(problems, fmt.Sprintf("unknown type %q", m[1]))
+		}
+	}
+	if enabled("trailing-period") && strings.HasSuffix(subject, ".") {
+		problems = append(problems, "subject ends with a period")
+	}
+	return problems
+}
+
+// commitSubject is a commit's short hash and subject line.
+type commitSubject struct {
+	hash, subject string
+}
+
+// commitMessageResults lints the subjects of unpushed commits and returns a
+// warning per commit that breaks a rule; none unless commitMessages.enabled
+// is set.
+func commitMessageResults(cfg CommitMessageConfig, commits []commitSubject) []Result {
+	if !cfg.Enabled {
+		return nil
+	}
+	var results []Result
+	for _, c := range commits {
+		problems := commitMessageProblems(c.subject, cfg)
+		if len(problems) == 0 {
+			continue
+		}
+		results = append(results, Result{
+			Name:    fmt.Sprintf("commit/message[%s]", c.hash),
+			Status:  StatusWarn,
+			Message: strings.Join(problems, ", "),
+			Details: []string{c.subject},
+		})
+	}
+	return results
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCommitMessageProblems(t *testing.T) {
+	tests := []struct {
+		subject string
+		cfg     CommitMessageConfig
+		want    []string
+	}{
+		{"fix: handle empty remotes", CommitMessageConfig{}, nil},
+		{"feat(cli)!: drop --legacy", CommitMessageConfig{}, nil},
+		{"Merge branch 'main' into topic", CommitMessageConfig{}, nil},
+		{"fixup! fix: handle empty remotes", CommitMessageConfig{}, nil},
+		{"handle empty remotes", CommitMessageConfig{}, []string{"no type prefix (e.g. fix: ...)"}},
+		{"bugfix: handle empty remotes", CommitMessageConfig{}, []string{`unknown type "bugfix"`}},
+		{"bugfix: handle empty remotes", CommitMessageConfig{Types: []string{"bugfix"}}, nil},
+		{"fix: handle empty remotes.", CommitMessageConfig{}, []string{"subject ends with a period"}},
+		{"fix: " + strings.Repeat("x", 70), CommitMessageConfig{}, []string{"subject is 75 characters (max 72)"}},
+		{"fix: " + strings.Repeat("x", 70), CommitMessageConfig{MaxSubjectLength: 80}, nil},
+		{"Handle empty remotes.", CommitMessageConfig{Rules: []string{"trailing-period"}}, []string{"subject ends with a period"}},
+	}
+	for _, tt := range tests {
+		if got := commitMessageProblems(tt.subject, tt.cfg); !slices.Equal(got, tt.want) {
+			t.Errorf("commitMessageProblems(%q, %+v) = %q, want %q", tt.subject, tt.cfg, got, tt.want)
+		}
+	}
+}
+
+func TestUnpushedLintsCommitMessages(t *testing.T) {
+	r := newTestRepo(t)
+	r.Config.Thresholds.UnpushedMaxAge = Duration{7 * 24 * time.Hour}
+	r.commit("a.txt", "a", "feat: add a", time.Now())
+	r.commit("b.txt", "b", "Add b.", time.Now())
+	short := r.git("rev-parse", "--short=7", "HEAD")
+
+	for _, res := range (&UnpushedCheck{}).Check(r.Repo) {
+		if strings.HasPrefix(res.Name, "commit/message") {
+			t.Errorf("not enabled: got %+v", res)
+		}
+	}
+
+	r.Config.CommitMessages.Enabled = true
+	results := (&UnpushedCheck{}).Check(r.Repo)
+	var messages []Result
+	for _, res := range results {
+		if strings.HasPrefix(res.Name, "commit/message") {
+			messages = append(messages, res)
+		}
+	}
+	if len(messages) != 1 || messages[0].Name != "commit/message["+short+"]" || messages[0].Status != StatusWarn {
+		t.Fatalf("message results = %+v, want one warning for %s", messages, short)
+	}
+	if want := "no type prefix (e.g. fix: ...), subject ends with a period"; messages[0].Message != want {
+		t.Errorf("message = %q, want %q", messages[0].Message, want)
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CommitterCheck looks at unpushed commits by the configured user for
+// author/committer drift: a rebase or amend under a different identity
+// leaves the author as one email and the committer as another (or either as
+// an email that isn't configured), which confuses attribution and can break
+// DCO or signing checks. Only unpushed commits are checked, since those can
+// still be fixed with a rebase.
+type CommitterCheck struct{}
+
+func (c *CommitterCheck) Name() string { return "committer" }
+
+func (c *CommitterCheck) Check(repo *Repo) []Result {
+	name := repo.Config.Identity.Name
+	accepted := acceptedEmails(repo)
+	if name == "" || len(accepted) == 0 {
+		return nil
+	}
+
+	out, err := repo.Git("log", "--branches", "--not", "--remotes", "--format=%h%x00%an%x00%ae%x00%cn%x00%ce%x00%s")
+	if err != nil || out == "" {
+		return nil
+	}
+
+	var details []string
+	for _, line := range strings.Split(out, "\n") {
+		f := strings.Split(line, "\x00")
+		if len(f) != 6 {
+			continue
+		}
+		hash, authorName, authorEmail, committerName, committerEmail, subject := f[0], f[1], f[2], f[3], f[4], f[5]
+		// Only the user's side of a commit can drift here; a commit
+		// split between the user and a colleague, such as a rebased
+		// colleague's commit, is CommitIdentityCheck's to report.
+		mineAuthor, mineCommitter := authorName == name, committerName == name
+		drift := (mineAuthor && !accepted[authorEmail]) ||
+			(mineCommitter && !accepted[committerEmail]) ||
+			(mineAuthor && mineCommitter && authorEmail != committerEmail)
+		if !drift {
+			continue
+		}
+		details = append(details, fmt.Sprintf("%s %s (author %s, committer %s)", hash, subject, authorEmail, committerEmail))
+	}
+
+	if len(details) > 0 {
+		return []Result{{
+			Name:    "identity/committer",
+			Status:  StatusWarn,
+			Message: fmt.Sprintf("%d unpushed commits have mismatched or unexpected author/committer emails", len(details)),
+			Details: details,
+		}}
+	}
+	return []Result{{
+		Name:    "identity/committer",
+		Status:  StatusOK,
+		Message: "unpushed commits use a consistent identity",
+	}}
+}
+
+func (c *CommitterCheck) Fix(_ *Repo, results []Result) []Result {
+	return results
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCommitterCheckFlagsDrift(t *testing.T) {
+	r := newTestRepo(t)
+	now := time.Now()
+	r.commit("a.txt", "a", "consistent", now)
+	// A colleague's commit is not the user's identity drift.
+	r.commitAs("b.txt", "b", "colleague", "Other Dev", "other@example.com", now)
+
+	if got, _ := resultByName((&CommitterCheck{}).Check(r.Repo), "identity/committer"); got.Status != StatusOK {
+		t.Fatalf("identity/committer = %+v, want ok", got)
+	}
+
+	// Commit as the user, but with a different committer email, as after a
+	// rebase under another identity.
+	if err := os.WriteFile(filepath.Join(r.dir, "c.txt"), []byte("c"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	r.git("add", "c.txt")
+	runGit(t, r.dir, []string{"GIT_COMMITTER_EMAIL=old@example.com"}, "commit", "--message", "drifted")
+
+	got, ok := resultByName((&CommitterCheck{}).Check(r.Repo), "identity/committer")
+	if !ok || got.Status != StatusWarn || len(got.Details) != 1 {
+		t.Fatalf("identity/committer = %+v, want warn with one commit", got)
+	}
+}
@@ -1,34 +1,106 @@
 package main
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
+
+	"gopkg.in/yaml.v3"
 )
 
 type Config struct {
-	WorkOrgs    []string         `json:"workOrgs"`
-	Protocol    string           `json:"protocol"`
-	Identity    IdentityConfig   `json:"identity"`
-	Thresholds  ThresholdsConfig `json:"thresholds"`
-	DetailLines int              `json:"detailLines"`
+	WorkOrgs              []string            `json:"workOrgs" yaml:"workOrgs"`
+	Protocol              string              `json:"protocol" yaml:"protocol"`
+	ProtocolHosts         []string            `json:"protocolHosts" yaml:"protocolHosts"`                 // hosts the protocol check converts; default github.com, gitlab.com
+	ProtocolIgnoreRemotes []string            `json:"protocolIgnoreRemotes" yaml:"protocolIgnoreRemotes"` // remote names the protocol check skips
+	RemoteURLForm         string              `json:"remoteURLForm" yaml:"remoteURLForm"`                 // "git" (default, with .git suffix) or "bare"
+	PushProtocols         map[string]string   `json:"pushProtocols" yaml:"pushProtocols"`                 // org -> protocol origin must push with (work repos)
+	Identity              IdentityConfig      `json:"identity" yaml:"identity"`
+	Thresholds            ThresholdsConfig    `json:"thresholds" yaml:"thresholds"`
+	Attribution           AttributionConfig   `json:"attribution" yaml:"attribution"`
+	Untracked             UntrackedConfig     `json:"untracked" yaml:"untracked"`
+	CommitMessages        CommitMessageConfig `json:"commitMessages" yaml:"commitMessages"`
+	RequiredTrackedFiles  []string            `json:"requiredTrackedFiles" yaml:"requiredTrackedFiles"` // globs that must not match an ignore rule
+	ExecutableFiles       []string            `json:"executableFiles" yaml:"executableFiles"`           // globs for files that must be executable; all others must not be
+	DependencyDirs        []string            `json:"dependencyDirs" yaml:"dependencyDirs"`             // directory names that must not be tracked; default depends on languages
+	MainBranches          []string            `json:"mainBranches" yaml:"mainBranches"`                 // main branch names to try after origin/HEAD and init.defaultBranch, before main and master
+	BranchPattern         string              `json:"branchPattern" yaml:"branchPattern"`               // regexp local branch names must match; empty disables the check
+	GenericBranchPattern  string              `json:"genericBranchPattern" yaml:"genericBranchPattern"` // regexp for throwaway branch names; default ^patch-\d+$
+	Checks                map[string]bool     `json:"checks" yaml:"checks"`                             // check name to enabled; unlisted checks are enabled
+	ExcludeDirs           []string            `json:"excludeDirs" yaml:"excludeDirs"`                   // -R and probe mode skip directories matching these globs
+	DetailLines           int                 `json:"detailLines" yaml:"detailLines"`
+	Online                bool                `json:"online" yaml:"online"`                     // enables opt-in checks that make extra GitHub API calls
+	State                 bool                `json:"state" yaml:"state"`                       // records when each finding was first seen
+	Whitespace            bool                `json:"whitespace" yaml:"whitespace"`             // enables the git diff --check whitespace check
+	SquashAuthors         bool                `json:"squashAuthors" yaml:"squashAuthors"`       // warns about multi-author feature branches
+	WarningsAsErrors      bool                `json:"warningsAsErrors" yaml:"warningsAsErrors"` // exit 1 on warnings too, e.g. in CI
+	TrustLocalConfig      bool                `json:"trustLocalConfig" yaml:"trustLocalConfig"` // honor each repo's .git-lint.json; see mergeConfig
 }
 
 type IdentityConfig struct {
-	Name          string `json:"name"`
-	WorkEmail     string `json:"workEmail"`
-	PersonalEmail string `json:"personalEmail"`
+	Name          string `json:"name" yaml:"name"`
+	WorkEmail     string `json:"workEmail" yaml:"workEmail"`
+	PersonalEmail string `json:"personalEmail" yaml:"personalEmail"`
+	GitHubLogin   string `json:"githubLogin" yaml:"githubLogin"`
+
+	// StrictPersonalEmail flags the work email in personal repos instead of
+	// accepting either email there.
+	StrictPersonalEmail bool `json:"strictPersonalEmail" yaml:"strictPersonalEmail"`
+}
+
+// AttributionConfig sets the Claude attribution values work repos must use.
+// Empty values (the default) require attribution to be empty.
+type AttributionConfig struct {
+	ExpectedCommit string `json:"expectedCommit" yaml:"expectedCommit"`
+	ExpectedPR     string `json:"expectedPR" yaml:"expectedPR"`
+}
+
+// UntrackedConfig controls how the staleness check treats untracked files.
+// Skip drops untracked reporting entirely; otherwise files matching an
+// Ignore pattern are not counted. A pattern matches the full path or the
+// base name (glob syntax), and a trailing slash matches a directory prefix.
+type UntrackedConfig struct {
+	Skip   bool     `json:"skip" yaml:"skip"`
+	Ignore []string `json:"ignore" yaml:"ignore"`
+}
+
+// CommitMessageConfig sets the conventional-commit rules the unpushed
+// check applies to the messages of the commits it scans. Enabled turns
+// message linting on; Rules picks from "subject-length", "type", and
+// "trailing-period" (all when empty). MaxSubjectLength defaults to 72 and
+// Types to the conventional-commit types.
+type CommitMessageConfig struct {
+	Enabled          bool     `json:"enabled" yaml:"enabled"`
+	Rules            []string `json:"rules" yaml:"rules"`
+	MaxSubjectLength int      `json:"maxSubjectLength" yaml:"maxSubjectLength"`
+	Types            []string `json:"types" yaml:"types"`
 }
 
 type ThresholdsConfig struct {
-	StashMaxAge       Duration `json:"stashMaxAge"`
-	StashMaxCount     int      `json:"stashMaxCount"`
-	UncommittedMaxAge Duration `json:"uncommittedMaxAge"`
-	UnpushedMaxAge    Duration `json:"unpushedMaxAge"`
+	StashMaxAge           Duration `json:"stashMaxAge" yaml:"stashMaxAge"`
+	StashMaxCount         int      `json:"stashMaxCount" yaml:"stashMaxCount"`
+	UncommittedMaxAge     Duration `json:"uncommittedMaxAge" yaml:"uncommittedMaxAge"`
+	UnpushedMaxAge        Duration `json:"unpushedMaxAge" yaml:"unpushedMaxAge"`
+	UntaggedMaxCommits    int      `json:"untaggedMaxCommits" yaml:"untaggedMaxCommits"`       // 0 disables the check
+	BehindMaxCommits      int      `json:"behindMaxCommits" yaml:"behindMaxCommits"`           // 0 disables the check
+	BranchMaxAhead        int      `json:"branchMaxAhead" yaml:"branchMaxAhead"`               // 0 disables the ahead limit
+	BranchMaxBehind       int      `json:"branchMaxBehind" yaml:"branchMaxBehind"`             // 0 disables the behind limit
+	ForkParentTTL         Duration `json:"forkParentTTL" yaml:"forkParentTTL"`                 // default 30d
+	RemoteBranchesMax     int      `json:"remoteBranchesMax" yaml:"remoteBranchesMax"`         // 0 disables the check
+	FleetStaleAfter       Duration `json:"fleetStaleAfter" yaml:"fleetStaleAfter"`             // -R only; 0 disables the summary
+	AbandonedMaxAge       Duration `json:"abandonedMaxAge" yaml:"abandonedMaxAge"`             // 0 disables the check
+	ReflogMaxEntries      int      `json:"reflogMaxEntries" yaml:"reflogMaxEntries"`           // 0 disables the check
+	LargeFileMaxBytes     int64    `json:"largeFileMaxBytes" yaml:"largeFileMaxBytes"`         // default 5MB; the check itself is enabled via checks
+	EmailLeakCommits      int      `json:"emailLeakCommits" yaml:"emailLeakCommits"`           // default 50
+	CommitIdentityCommits int      `json:"commitIdentityCommits" yaml:"commitIdentityCommits"` // default 100
+	TagMaxAge             Duration `json:"tagMaxAge" yaml:"tagMaxAge"`                         // 0 disables the unreachable-tag check
 }
 
 // Duration wraps time.Duration with JSON unmarshaling from strings like "7d", "1d", "12h".
@@ -53,6 +125,23 @@ func (d Duration) MarshalJSON() ([]byte, error) {
 	return json.Marshal(formatDurationConfig(d.Duration))
 }
 
+func (d *Duration) UnmarshalYAML(value *yaml.Node) error {
+	var s string
+	if err := value.Decode(&s); err != nil {
+		return err
+	}
+	parsed, err := parseDuration(s)
+	if err != nil {
+		return err
+	}
+	d.Duration = parsed
+	return nil
+}
+
+func (d Duration) MarshalYAML() (any, error) {
+	return formatDurationConfig(d.Duration), nil
+}
+
 // formatDurationConfig formats a duration for config display, using days where appropriate.
 func formatDurationConfig(d time.Duration) string {
 	if d == 0 {
@@ -65,38 +154,296 @@ func formatDurationConfig(d time.Duration) string {
 	return d.String()
 }
 
+// parseDuration parses a duration that may use "w" (weeks) and "d" (days)
+// on top of time.ParseDuration's units, alone or combined: "2w", "1w3d",
+// "1d12h". Strings without w or d go straight to time.ParseDuration.
 func parseDuration(s string) (time.Duration, error) {
-	// Support "Nd" for days, otherwise delegate to time.ParseDuration.
-	if strings.HasSuffix(s, "d") {
-		days, err := strconv.Atoi(strings.TrimSuffix(s, "d"))
+	if !strings.ContainsAny(s, "wd") {
+		return time.ParseDuration(s)
+	}
+	var total time.Duration
+	var rest strings.Builder
+	for i := 0; i < len(s); {
+		// Each group is a number followed by its unit.
+		j := i
+		for j < len(s) && (s[j] >= '0' && s[j] <= '9' || s[j] == '.') {
+			j++
+		}
+		k := j
+		for k < len(s) && !(s[k] >= '0' && s[k] <= '9' || s[k] == '.') {
+			k++
+		}
+		if j == i || k == j {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		switch unit := s[j:k]; unit {
+		case "w", "d":
+			n, err := strconv.Atoi(s[i:j])
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration %q: %w", s, err)
+			}
+			day := 24 * time.Hour
+			if unit == "w" {
+				day *= 7
+			}
+			total += time.Duration(n) * day
+		default:
+			rest.WriteString(s[i:k])
+		}
+		i = k
+	}
+	if rest.Len() > 0 {
+		d, err := time.ParseDuration(rest.String())
 		if err != nil {
 			return 0, fmt.Errorf("invalid duration %q: %w", s, err)
 		}
-		return time.Duration(days) * 24 * time.Hour, nil
+		total += d
 	}
-	return time.ParseDuration(s)
+	return total, nil
 }
 
+// configDirEnv names the environment variable that relocates every file
+// git-lint reads or writes (config and state) into one directory. main sets
+// it from --config-dir so the override also reaches child processes.
+const configDirEnv = "GIT_LINT_CONFIG_DIR"
+
+// configPath returns the default config file: config.json in the config
+// directory, or config.yaml (or config.yml) when only that exists. A new
+// config is written as config.json.
 func configPath() string {
+	dir := configDir()
+	path := filepath.Join(dir, "config.json")
+	if _, err := os.Stat(path); err == nil {
+		return path
+	}
+	for _, name := range []string{"config.yaml", "config.yml"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			return filepath.Join(dir, name)
+		}
+	}
+	return path
+}
+
+func configDir() string {
+	if dir := os.Getenv(configDirEnv); dir != "" {
+		return dir
+	}
 	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
-		return filepath.Join(dir, "git-lint", "config.json")
+		return filepath.Join(dir, "git-lint")
 	}
 	home, _ := os.UserHomeDir()
-	return filepath.Join(home, ".config", "git-lint", "config.json")
+	return filepath.Join(home, ".config", "git-lint")
+}
+
+// isYAMLPath reports whether a config file is YAML by its extension; all
+// other files are JSON.
+func isYAMLPath(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// defaultConfig is the config used when no config file exists yet. Its
+// thresholds keep a first run from failing every repo with a stash or an
+// uncommitted change; everything that needs personal data (orgs, identity)
+// stays unset.
+func defaultConfig() *Config {
+	return &Config{
+		Thresholds: ThresholdsConfig{
+			StashMaxAge:       Duration{7 * 24 * time.Hour},
+			StashMaxCount:     10,
+			UncommittedMaxAge: Duration{24 * time.Hour},
+		},
+	}
 }
 
-func loadConfig() (*Config, error) {
-	path := configPath()
+// loadConfig reads the config from path, or from configPath() when path is
+// empty. A missing default config yields defaultConfig(), but a missing
+// explicit path is an error so a typo in --config doesn't go unnoticed.
+// GIT_LINT_* environment variables (see applyEnv) are applied on top.
+func loadConfig(path string) (*Config, error) {
+	explicit := path != ""
+	if !explicit {
+		path = configPath()
+	}
 	data, err := os.ReadFile(path)
 	if err != nil {
-		if os.IsNotExist(err) {
-			return &Config{}, nil
+		if os.IsNotExist(err) && !explicit {
+			cfg := defaultConfig()
+			return cfg, applyEnv(cfg)
 		}
 		return nil, fmt.Errorf("reading config %s: %w", path, err)
 	}
+	// The environment overrides the default config file, but keys an
+	// explicit --config file sets win over it: decoding only overwrites the
+	// keys the file has.
 	var cfg Config
-	if err := json.Unmarshal(data, &cfg); err != nil {
+	if explicit {
+		if err := applyEnv(&cfg); err != nil {
+			return nil, err
+		}
+	}
+	if err := unmarshalConfig(path, data, &cfg); err != nil {
 		return nil, fmt.Errorf("parsing config %s: %w", path, err)
 	}
+	if !explicit {
+		if err := applyEnv(&cfg); err != nil {
+			return nil, err
+		}
+	}
 	return &cfg, nil
 }
+
+func unmarshalConfig(path string, data []byte, cfg *Config) error {
+	if isYAMLPath(path) {
+		return yaml.Unmarshal(data, cfg)
+	}
+	return json.Unmarshal(data, cfg)
+}
+
+// applyEnv sets config values from GIT_LINT_* environment variables, for
+// containers where mounting a config file is awkward: GIT_LINT_WORK_ORGS
+// (comma-separated), the identity emails and name, and the thresholds, each
+// named after its key (GIT_LINT_STASH_MAX_AGE for stashMaxAge).
+func applyEnv(cfg *Config) error {
+	if v := os.Getenv("GIT_LINT_WORK_ORGS"); v != "" {
+		cfg.WorkOrgs = nil
+		for _, org := range strings.Split(v, ",") {
+			if org = strings.TrimSpace(org); org != "" {
+				cfg.WorkOrgs = append(cfg.WorkOrgs, org)
+			}
+		}
+	}
+	for name, dst := range map[string]*string{
+		"GIT_LINT_IDENTITY_NAME":  &cfg.Identity.Name,
+		"GIT_LINT_WORK_EMAIL":     &cfg.Identity.WorkEmail,
+		"GIT_LINT_PERSONAL_EMAIL": &cfg.Identity.PersonalEmail,
+	} {
+		if v := os.Getenv(name); v != "" {
+			*dst = v
+		}
+	}
+	t := &cfg.Thresholds
+	for name, dst := range map[string]*Duration{
+		"GIT_LINT_STASH_MAX_AGE":       &t.StashMaxAge,
+		"GIT_LINT_UNCOMMITTED_MAX_AGE": &t.UncommittedMaxAge,
+		"GIT_LINT_UNPUSHED_MAX_AGE":    &t.UnpushedMaxAge,
+		"GIT_LINT_FORK_PARENT_TTL":     &t.ForkParentTTL,
+		"GIT_LINT_FLEET_STALE_AFTER":   &t.FleetStaleAfter,
+		"GIT_LINT_ABANDONED_MAX_AGE":   &t.AbandonedMaxAge,
+		"GIT_LINT_TAG_MAX_AGE":         &t.TagMaxAge,
+	} {
+		if v := os.Getenv(name); v != "" {
+			d, err := parseDuration(v)
+			if err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+			dst.Duration = d
+		}
+	}
+	for name, dst := range map[string]*int{
+		"GIT_LINT_STASH_MAX_COUNT":         &t.StashMaxCount,
+		"GIT_LINT_UNTAGGED_MAX_COMMITS":    &t.UntaggedMaxCommits,
+		"GIT_LINT_BEHIND_MAX_COMMITS":      &t.BehindMaxCommits,
+		"GIT_LINT_BRANCH_MAX_AHEAD":        &t.BranchMaxAhead,
+		"GIT_LINT_BRANCH_MAX_BEHIND":       &t.BranchMaxBehind,
+		"GIT_LINT_REMOTE_BRANCHES_MAX":     &t.RemoteBranchesMax,
+		"GIT_LINT_REFLOG_MAX_ENTRIES":      &t.ReflogMaxEntries,
+		"GIT_LINT_EMAIL_LEAK_COMMITS":      &t.EmailLeakCommits,
+		"GIT_LINT_COMMIT_IDENTITY_COMMITS": &t.CommitIdentityCommits,
+	} {
+		if v := os.Getenv(name); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+			*dst = n
+		}
+	}
+	if v := os.Getenv("GIT_LINT_LARGE_FILE_MAX_BYTES"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return fmt.Errorf("GIT_LINT_LARGE_FILE_MAX_BYTES: %w", err)
+		}
+		t.LargeFileMaxBytes = n
+	}
+	return nil
+}
+
+// localConfigFile is the per-repo config overlay at the repo root.
+const localConfigFile = ".git-lint.json"
+
+// localConfig lists the settings a repo's overlay may change. Each field
+// points into the merged config, so decoding sets only the keys the overlay
+// has and the rest keep the global values.
+type localConfig struct {
+	WorkOrgs   *[]string         `json:"workOrgs"`
+	Identity   *IdentityConfig   `json:"identity"`
+	Thresholds *ThresholdsConfig `json:"thresholds"`
+}
+
+// mergeConfig returns a copy of base with a repo's .git-lint.json overlay
+// applied: workOrgs, identity, and thresholds keys the overlay sets win,
+// everything else is inherited, down to individual identity and threshold
+// fields. Other keys are an error rather than silently ignored. Since an
+// overlay can change identity rules, callers apply it only when the user
+// trusts local configs.
+func mergeConfig(base *Config, overlay []byte) (*Config, error) {
+	merged := *base
+	merged.WorkOrgs = slices.Clone(base.WorkOrgs)
+	dec := json.NewDecoder(bytes.NewReader(overlay))
+	dec.DisallowUnknownFields()
+	err := dec.Decode(&localConfig{
+		WorkOrgs:   &merged.WorkOrgs,
+		Identity:   &merged.Identity,
+		Thresholds: &merged.Thresholds,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &merged, nil
+}
+
+// warnings returns setup mistakes in the config that don't prevent running
+// but make the results confusing. main prints them once, before any repo is
+// checked.
+func (cfg *Config) warnings() []string {
+	var warnings []string
+	id := cfg.Identity
+	if id.WorkEmail != "" && strings.EqualFold(id.WorkEmail, id.PersonalEmail) {
+		warnings = append(warnings, fmt.Sprintf("identity.workEmail and identity.personalEmail are both %s; work and personal repos cannot be told apart by email", id.WorkEmail))
+	}
+	for _, rule := range cfg.CommitMessages.Rules {
+		if !slices.Contains(commitMessageRules, rule) {
+			warnings = append(warnings, fmt.Sprintf("commitMessages.rules: unknown rule %q", rule))
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(cfg.Checks)) {
+		if !isCheckName(name) {
+			warnings = append(warnings, fmt.Sprintf("checks: unknown check %q", name))
+		}
+	}
+	return warnings
+}
+
+// writeConfig writes cfg to path as indented JSON, or as YAML for a .yaml
+// or .yml path, creating its directory.
+func writeConfig(path string, cfg *Config) error {
+	var data []byte
+	var err error
+	if isYAMLPath(path) {
+		data, err = yaml.Marshal(cfg)
+		data = bytes.TrimSuffix(data, []byte("\n"))
+	} else {
+		data, err = json.MarshalIndent(cfg, "", "  ")
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating config directory: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("writing config %s: %w", path, err)
+	}
+	return nil
+}
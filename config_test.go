@@ -2,6 +2,10 @@ package main
 
 import (
 	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 )
@@ -16,6 +20,15 @@ func TestParseDuration(t *testing.T) {
 		{"1d", 24 * time.Hour, false},
 		{"12h", 12 * time.Hour, false},
 		{"30m", 30 * time.Minute, false},
+		{"2w", 14 * 24 * time.Hour, false},
+		{"1w3d", 10 * 24 * time.Hour, false},
+		{"1d12h", 36 * time.Hour, false},
+		{"36h", 36 * time.Hour, false},
+		{"2d1h30m", 49*time.Hour + 30*time.Minute, false},
+		{"1h30m", 90 * time.Minute, false},
+		{"1.5d", 0, true},
+		{"d", 0, true},
+		{"3d2x", 0, true},
 		{"xd", 0, true},
 		{"", 0, true},
 	}
@@ -78,3 +91,234 @@ func TestDurationJSONRoundTrip(t *testing.T) {
 		t.Errorf("Marshal(7d) = %s, want %q", out, `"7d"`)
 	}
 }
+
+func TestConfigWarningsSameEmail(t *testing.T) {
+	tests := []struct {
+		work, personal string
+		want           int
+	}{
+		{"me@corp.com", "me@example.com", 0},
+		{"me@example.com", "me@example.com", 1},
+		{"Me@Example.com", "me@example.com", 1},
+		{"", "", 0},
+		{"", "me@example.com", 0},
+	}
+	for _, tt := range tests {
+		cfg := &Config{Identity: IdentityConfig{WorkEmail: tt.work, PersonalEmail: tt.personal}}
+		if got := cfg.warnings(); len(got) != tt.want {
+			t.Errorf("warnings(%q, %q) = %q, want %d", tt.work, tt.personal, got, tt.want)
+		}
+	}
+}
+
+func TestLoadConfigExplicitPath(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "work.json")
+	if err := os.WriteFile(path, []byte(`{"workOrgs": ["acme"]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := loadConfig(path)
+	if err != nil {
+		t.Fatalf("loadConfig(%q) error: %v", path, err)
+	}
+	if len(cfg.WorkOrgs) != 1 || cfg.WorkOrgs[0] != "acme" {
+		t.Errorf("WorkOrgs = %v, want [acme]", cfg.WorkOrgs)
+	}
+
+	if _, err := loadConfig(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("loadConfig with a missing explicit path should fail")
+	}
+
+	// The default path may be missing; that yields the default config.
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	cfg, err = loadConfig("")
+	if err != nil {
+		t.Fatalf("loadConfig(\"\") with no config file: %v", err)
+	}
+	if len(cfg.WorkOrgs) != 0 || cfg.Thresholds.StashMaxAge.Duration != 7*24*time.Hour || cfg.Thresholds.StashMaxCount != 10 {
+		t.Errorf("loadConfig(\"\") = %+v, want defaultConfig()", cfg)
+	}
+
+	// A malformed default config is still an error.
+	if err := os.MkdirAll(filepath.Join(dir, "git-lint"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "git-lint", "config.json"), []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadConfig(""); err == nil {
+		t.Error("loadConfig(\"\") with malformed config should fail")
+	}
+}
+
+func TestLoadConfigYAML(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(configDirEnv, dir)
+	yamlPath := filepath.Join(dir, "config.yaml")
+	data := "# work laptop\nworkOrgs: [acme]\nidentity:\n  name: Alice\nthresholds:\n  stashMaxAge: 1w3d\n"
+	if err := os.WriteFile(yamlPath, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got := configPath(); got != yamlPath {
+		t.Errorf("configPath() = %q, want %q when only YAML exists", got, yamlPath)
+	}
+	cfg, err := loadConfig("")
+	if err != nil {
+		t.Fatalf("loadConfig(\"\") error: %v", err)
+	}
+	if !slices.Equal(cfg.WorkOrgs, []string{"acme"}) || cfg.Identity.Name != "Alice" || cfg.Thresholds.StashMaxAge.Duration != 10*24*time.Hour {
+		t.Errorf("loadConfig(YAML) = %+v", cfg)
+	}
+
+	// JSON stays the default when both exist.
+	jsonPath := filepath.Join(dir, "config.json")
+	if err := os.WriteFile(jsonPath, []byte(`{"workOrgs": ["json"]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got := configPath(); got != jsonPath {
+		t.Errorf("configPath() = %q, want %q when both exist", got, jsonPath)
+	}
+
+	// writeConfig writes YAML that loads back the same.
+	out := filepath.Join(dir, "out.yml")
+	if err := writeConfig(out, cfg); err != nil {
+		t.Fatal(err)
+	}
+	back, err := loadConfig(out)
+	if err != nil {
+		t.Fatalf("loadConfig(%q) error: %v", out, err)
+	}
+	if !slices.Equal(back.WorkOrgs, cfg.WorkOrgs) || back.Identity != cfg.Identity || back.Thresholds != cfg.Thresholds {
+		t.Errorf("YAML round trip = %+v, want %+v", back, cfg)
+	}
+}
+
+func TestLoadConfigEnv(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(configDirEnv, dir)
+	t.Setenv("GIT_LINT_WORK_ORGS", "acme, corp-*")
+	t.Setenv("GIT_LINT_WORK_EMAIL", "me@acme.com")
+	t.Setenv("GIT_LINT_STASH_MAX_AGE", "2w")
+	t.Setenv("GIT_LINT_STASH_MAX_COUNT", "3")
+
+	// Without a config file, the environment overrides the defaults.
+	cfg, err := loadConfig("")
+	if err != nil {
+		t.Fatalf("loadConfig(\"\") error: %v", err)
+	}
+	if !slices.Equal(cfg.WorkOrgs, []string{"acme", "corp-*"}) || cfg.Identity.WorkEmail != "me@acme.com" ||
+		cfg.Thresholds.StashMaxAge.Duration != 14*24*time.Hour || cfg.Thresholds.StashMaxCount != 3 {
+		t.Errorf("loadConfig with env = %+v", cfg)
+	}
+
+	// The environment wins over the default config file...
+	data := []byte(`{"workOrgs": ["file"], "thresholds": {"stashMaxCount": 9, "unpushedMaxAge": "1d"}}`)
+	if err := os.WriteFile(filepath.Join(dir, "config.json"), data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = loadConfig("")
+	if err != nil {
+		t.Fatalf("loadConfig(\"\") error: %v", err)
+	}
+	if !slices.Equal(cfg.WorkOrgs, []string{"acme", "corp-*"}) || cfg.Thresholds.StashMaxCount != 3 || cfg.Thresholds.UnpushedMaxAge.Duration != 24*time.Hour {
+		t.Errorf("env over default file = %+v", cfg)
+	}
+
+	// ...but keys an explicit --config file sets win over the environment.
+	explicit := filepath.Join(dir, "work.json")
+	if err := os.WriteFile(explicit, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = loadConfig(explicit)
+	if err != nil {
+		t.Fatalf("loadConfig(%q) error: %v", explicit, err)
+	}
+	if !slices.Equal(cfg.WorkOrgs, []string{"file"}) || cfg.Thresholds.StashMaxCount != 9 || cfg.Identity.WorkEmail != "me@acme.com" {
+		t.Errorf("explicit file over env = %+v", cfg)
+	}
+
+	t.Setenv("GIT_LINT_STASH_MAX_AGE", "soon")
+	if _, err := loadConfig(""); err == nil || !strings.Contains(err.Error(), "GIT_LINT_STASH_MAX_AGE") {
+		t.Errorf("invalid GIT_LINT_STASH_MAX_AGE: error = %v", err)
+	}
+}
+
+func TestConfigDirOverride(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", "/xdg/config")
+	t.Setenv("XDG_STATE_HOME", "/xdg/state")
+	t.Setenv(configDirEnv, "")
+	if got := configPath(); got != filepath.Join("/xdg/config", "git-lint", "config.json") {
+		t.Errorf("configPath() = %q without override", got)
+	}
+
+	t.Setenv(configDirEnv, "/sandbox")
+	if got := configPath(); got != filepath.Join("/sandbox", "config.json") {
+		t.Errorf("configPath() = %q, want it under %s", got, configDirEnv)
+	}
+	if got := statePath(); got != filepath.Join("/sandbox", "state.json") {
+		t.Errorf("statePath() = %q, want it under %s", got, configDirEnv)
+	}
+}
+
+func TestConfigWarningsUnknownCheck(t *testing.T) {
+	cfg := &Config{Checks: map[string]bool{"attribution": false, "atribution": false}}
+	got := cfg.warnings()
+	if len(got) != 1 || !strings.Contains(got[0], `"atribution"`) {
+		t.Errorf("warnings = %q, want one for the misspelled check", got)
+	}
+}
+
+func TestMergeConfig(t *testing.T) {
+	base := &Config{
+		WorkOrgs: []string{"acme"},
+		Protocol: "ssh",
+		Identity: IdentityConfig{Name: "Alice", WorkEmail: "alice@acme.com"},
+		Thresholds: ThresholdsConfig{
+			StashMaxAge:   Duration{7 * 24 * time.Hour},
+			StashMaxCount: 2,
+		},
+	}
+	merged, err := mergeConfig(base, []byte(`{
+		"workOrgs": ["other"],
+		"identity": {"workEmail": "alice@other.com"},
+		"thresholds": {"stashMaxCount": 0, "unpushedMaxAge": "2d"}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(merged.WorkOrgs, []string{"other"}) || merged.Protocol != "ssh" {
+		t.Errorf("workOrgs = %v, protocol = %q; want overlay orgs and inherited protocol", merged.WorkOrgs, merged.Protocol)
+	}
+	if merged.Identity.Name != "Alice" || merged.Identity.WorkEmail != "alice@other.com" {
+		t.Errorf("identity = %+v, want inherited name and overlay work email", merged.Identity)
+	}
+	th := merged.Thresholds
+	if th.StashMaxAge.Duration != 7*24*time.Hour || th.StashMaxCount != 0 || th.UnpushedMaxAge.Duration != 48*time.Hour {
+		t.Errorf("thresholds = %+v, want inherited stashMaxAge, overlay stashMaxCount 0 and unpushedMaxAge", th)
+	}
+	if !slices.Equal(base.WorkOrgs, []string{"acme"}) || base.Identity.WorkEmail != "alice@acme.com" || base.Thresholds.StashMaxCount != 2 {
+		t.Errorf("base config changed: %+v", base)
+	}
+
+	if _, err := mergeConfig(base, []byte(`{"protocol": "https"}`)); err == nil {
+		t.Error("overlay with protocol: want error, only workOrgs, identity, and thresholds may be set")
+	}
+}
+
+func TestLocalConfigRequiresTrust(t *testing.T) {
+	r := newTestRepo(t)
+	overlay := `{"identity": {"name": "Overlay Name"}}`
+	if err := os.WriteFile(filepath.Join(r.dir, localConfigFile), []byte(overlay), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	r.reload()
+	if r.Config.Identity.Name != "Test User" {
+		t.Errorf("untrusted overlay applied: name = %q", r.Config.Identity.Name)
+	}
+
+	r.Config.TrustLocalConfig = true
+	r.reload()
+	if r.Config.Identity.Name != "Overlay Name" {
+		t.Errorf("trusted overlay not applied: name = %q", r.Config.Identity.Name)
+	}
+}
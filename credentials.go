@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CredentialHelperCheck warns about HTTPS remotes with no credential helper
+// configured for their URL: every push then prompts for a password, or fails
+// in automation. Remotes with pushes disabled only fetch, which works for
+// public repos without credentials, so for those the result is informational.
+type CredentialHelperCheck struct{}
+
+func (c *CredentialHelperCheck) Name() string { return "credential-helper" }
+
+func (c *CredentialHelperCheck) Check(repo *Repo) []Result {
+	remotes, _ := repo.Remotes()
+
+	var results []Result
+	for _, name := range remotes {
+		url := repo.RemoteURL(name)
+		if !strings.HasPrefix(url, "https://") {
+			continue
+		}
+		// --get-urlmatch honors URL-scoped credential.<url>.helper entries,
+		// such as the ones gh auth setup-git writes.
+		if helper, _ := repo.Git("config", "--get-urlmatch", "credential.helper", url); helper != "" {
+			continue
+		}
+		pushURL, _ := repo.Git("remote", "get-url", "--push", name)
+		if pushURL == "DISABLED" {
+			results = append(results, Result{
+				Name:    fmt.Sprintf("remote/credential-helper[%s]", name),
+				Status:  StatusOK,
+				Message: "no credential helper; pushes are disabled",
+			})
+			continue
+		}
+		results = append(results, Result{
+			Name:    fmt.Sprintf("remote/credential-helper[%s]", name),
+			Status:  StatusWarn,
+			Message: fmt.Sprintf("no credential helper for %s; pushes will prompt or fail (try gh auth setup-git)", url),
+		})
+	}
+	return results
+}
+
+func (c *CredentialHelperCheck) Fix(_ *Repo, results []Result) []Result {
+	return results
+}
@@ -0,0 +1,29 @@
+package main
+
+import "testing"
+
+func TestCredentialHelperCheck(t *testing.T) {
+	r := newTestRepo(t)
+	r.git("remote", "add", "origin", "https://github.com/me/repo.git")
+	r.git("remote", "add", "upstream", "https://github.com/acme/repo.git")
+	r.git("remote", "add", "mirror", "git@github.com:me/mirror.git")
+	r.git("config", "remote.upstream.pushurl", "DISABLED")
+	r.reload()
+
+	results := (&CredentialHelperCheck{}).Check(r.Repo)
+	if got, _ := resultByName(results, "remote/credential-helper[origin]"); got.Status != StatusWarn {
+		t.Errorf("origin = %+v, want warn", got)
+	}
+	if got, _ := resultByName(results, "remote/credential-helper[upstream]"); got.Status != StatusOK {
+		t.Errorf("upstream with pushes disabled = %+v, want ok", got)
+	}
+	if _, ok := resultByName(results, "remote/credential-helper[mirror]"); ok {
+		t.Error("ssh remote should not be checked")
+	}
+
+	// A helper scoped to the GitHub URL counts.
+	r.git("config", "credential.https://github.com.helper", "!gh auth git-credential")
+	if got, _ := resultByName((&CredentialHelperCheck{}).Check(r.Repo), "remote/credential-helper[origin]"); got.Status != "" {
+		t.Errorf("origin with scoped helper = %+v, want no result", got)
+	}
+}
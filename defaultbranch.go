@@ -0,0 +1,30 @@
+package main
+
+import "fmt"
+
+// DefaultBranchCheck notes when the effective init.defaultBranch differs
+// from the repo's main branch, e.g. main configured globally in a repo that
+// still uses master. Like ConfigOverrideCheck it is informational: the result
+// is ok and shows with --verbose, explaining why new repos or tools pick a
+// different name. Renaming the default branch is deliberate, so there is no
+// fix.
+type DefaultBranchCheck struct{}
+
+func (c *DefaultBranchCheck) Name() string { return "default-branch" }
+
+func (c *DefaultBranchCheck) Check(repo *Repo) []Result {
+	want := repo.GitConfigEffective("init.defaultBranch")
+	mainBranch := repo.MainBranch()
+	if want == "" || mainBranch == "" || want == mainBranch {
+		return nil
+	}
+	return []Result{{
+		Name:    "config/default-branch",
+		Status:  StatusOK,
+		Message: fmt.Sprintf("main branch is %s, but init.defaultBranch is %s", mainBranch, want),
+	}}
+}
+
+func (c *DefaultBranchCheck) Fix(_ *Repo, results []Result) []Result {
+	return results
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDefaultBranchCheck(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("a.txt", "a", "first", time.Now())
+	if results := (&DefaultBranchCheck{}).Check(r.Repo); len(results) != 0 {
+		t.Errorf("init.defaultBranch unset: got %+v, want none", results)
+	}
+
+	r.git("config", "--global", "init.defaultBranch", "main")
+	if results := (&DefaultBranchCheck{}).Check(r.Repo); len(results) != 0 {
+		t.Errorf("matching init.defaultBranch: got %+v, want none", results)
+	}
+
+	r.git("branch", "-m", "main", "master")
+	r.reload()
+	got, ok := resultByName((&DefaultBranchCheck{}).Check(r.Repo), "config/default-branch")
+	if !ok || got.Status != StatusOK || got.Fixable {
+		t.Errorf("master vs main = %+v, want informational ok", got)
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// DependencyDirCheck warns about tracked files inside dependency directories
+// such as node_modules, which bloat the repo and usually got committed by
+// accident. The directory names come from dependencyDirs, or from defaults
+// that depend on the project's languages. The fix untracks each directory
+// with git rm -r --cached, leaving the files on disk.
+type DependencyDirCheck struct{}
+
+func (c *DependencyDirCheck) Name() string { return "dependency-dirs" }
+
+func (c *DependencyDirCheck) Check(repo *Repo) []Result {
+	names := repo.Config.DependencyDirs
+	if names == nil {
+		names = defaultDependencyDirs(repo.Dir)
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	var out string
+	var err error
+	if repo.Ref != "" {
+		out, err = repo.Git("ls-tree", "-r", "--name-only", repo.Ref)
+	} else {
+		out, err = repo.Git("ls-files")
+	}
+	if err != nil || out == "" {
+		return nil
+	}
+
+	counts := dependencyDirCounts(strings.Split(out, "\n"), names)
+	if len(counts) == 0 {
+		return []Result{{
+			Name:    "files/dependency-dir",
+			Status:  StatusOK,
+			Message: "no tracked dependency directories",
+		}}
+	}
+	var results []Result
+	for _, dir := range slices.Sorted(maps.Keys(counts)) {
+		results = append(results, Result{
+			Name:    fmt.Sprintf("files/dependency-dir[%s]", dir),
+			Status:  StatusWarn,
+			Message: fmt.Sprintf("%d tracked files in dependency directory %s/", counts[dir], dir),
+			Fixable: repo.Ref == "",
+		})
+	}
+	return results
+}
+
+func (c *DependencyDirCheck) Fix(repo *Repo, results []Result) []Result {
+	var fixed []Result
+	for _, r := range results {
+		_, dir := splitResultName(r.Name)
+		if !r.Fixable || dir == "" {
+			fixed = append(fixed, r)
+			continue
+		}
+		if _, err := repo.Git("rm", "-r", "--cached", "--quiet", "--", dir); err != nil {
+			fixed = append(fixed, r)
+			continue
+		}
+		fixed = append(fixed, Result{
+			Name:    r.Name,
+			Status:  StatusFix,
+			Message: fmt.Sprintf("untracked %s/ (commit the removal)", dir),
+		})
+	}
+	return fixed
+}
+
+// defaultDependencyDirs picks dependency directory names for the languages
+// the repo root suggests. vendor/ and target/ are legitimate source
+// directories in other ecosystems, so they only count next to go.mod and
+// Cargo.toml or pom.xml.
+func defaultDependencyDirs(dir string) []string {
+	names := []string{"node_modules", ".venv"}
+	exists := func(name string) bool {
+		_, err := os.Stat(filepath.Join(dir, name))
+		return err == nil
+	}
+	if exists("go.mod") {
+		names = append(names, "vendor")
+	}
+	if exists("Cargo.toml") || exists("pom.xml") {
+		names = append(names, "target")
+	}
+	return names
+}
+
+// dependencyDirCounts counts tracked files under the outermost directory
+// whose name is in names, keyed by that directory's path.
+func dependencyDirCounts(files, names []string) map[string]int {
+	counts := map[string]int{}
+	for _, file := range files {
+		parts := strings.Split(file, "/")
+		for i, part := range parts[:len(parts)-1] {
+			if slices.Contains(names, part) {
+				counts[strings.Join(parts[:i+1], "/")]++
+				break
+			}
+		}
+	}
+	return counts
+}
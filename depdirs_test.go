@@ -0,0 +1,59 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDependencyDirCounts(t *testing.T) {
+	files := []string{
+		"node_modules/a/index.js",
+		"node_modules/b/node_modules/c.js",
+		"web/node_modules/x.js",
+		"src/vendor.go",
+		"vendor/modules.txt",
+	}
+	got := dependencyDirCounts(files, []string{"node_modules"})
+	want := map[string]int{"node_modules": 2, "web/node_modules": 1}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("dependencyDirCounts = %v, want %v", got, want)
+	}
+}
+
+func TestDefaultDependencyDirs(t *testing.T) {
+	dir := t.TempDir()
+	if got := defaultDependencyDirs(dir); !reflect.DeepEqual(got, []string{"node_modules", ".venv"}) {
+		t.Errorf("plain repo = %v", got)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module x\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got := defaultDependencyDirs(dir); !reflect.DeepEqual(got, []string{"node_modules", ".venv", "vendor"}) {
+		t.Errorf("Go repo = %v", got)
+	}
+}
+
+func TestDependencyDirCheckFixUntracks(t *testing.T) {
+	r := newTestRepo(t)
+	if err := os.MkdirAll(filepath.Join(r.dir, "node_modules", "left-pad"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	r.commit("node_modules/left-pad/index.js", "pad", "oops", time.Now())
+
+	results := (&DependencyDirCheck{}).Check(r.Repo)
+	got, ok := resultByName(results, "files/dependency-dir[node_modules]")
+	if !ok || got.Status != StatusWarn || !got.Fixable {
+		t.Fatalf("dependency-dir = %+v, want fixable warn", results)
+	}
+
+	(&DependencyDirCheck{}).Fix(r.Repo, results)
+	if tracked := r.git("ls-files", "node_modules"); tracked != "" {
+		t.Errorf("still tracked after fix: %q", tracked)
+	}
+	if _, err := os.Stat(filepath.Join(r.dir, "node_modules", "left-pad", "index.js")); err != nil {
+		t.Errorf("fix removed the file from disk: %v", err)
+	}
+}
@@ -10,6 +10,8 @@ import (
 // has a .github directory but no Dependabot configuration.
 type DependabotCheck struct{}
 
+func (c *DependabotCheck) Name() string { return "dependabot" }
+
 func (c *DependabotCheck) Check(repo *Repo) []Result {
 	originURL := repo.RemoteURL("origin")
 	owner, _ := parseGitHubRepo(originURL)
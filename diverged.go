@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DivergedCheck warns about local branches that have drifted far from their
+// upstream: many commits ahead is unpushed work piling up, many behind is a
+// painful rebase in waiting. It is opt-in, running only when
+// thresholds.branchMaxAhead or branchMaxBehind is set. Branches without an
+// upstream and PR checkouts are left to the branch cleanup check.
+type DivergedCheck struct{}
+
+func (c *DivergedCheck) Name() string { return "diverged" }
+
+func (c *DivergedCheck) Check(repo *Repo) []Result {
+	maxAhead := repo.Config.Thresholds.BranchMaxAhead
+	maxBehind := repo.Config.Thresholds.BranchMaxBehind
+	if maxAhead == 0 && maxBehind == 0 {
+		return nil
+	}
+	out, err := repo.Git("for-each-ref", "--format=%(refname:short)%00%(upstream:short)", "refs/heads/")
+	if err != nil || out == "" {
+		return nil
+	}
+
+	var results []Result
+	checked := 0
+	for _, line := range strings.Split(out, "\n") {
+		branch, upstream, _ := strings.Cut(line, "\x00")
+		if upstream == "" {
+			continue
+		}
+		if mergeRef := repo.GitConfig(fmt.Sprintf("branch.%s.merge", branch)); strings.HasPrefix(mergeRef, "refs/pull/") {
+			continue
+		}
+		ahead, behind, ok := aheadBehind(repo, branch, upstream)
+		if !ok {
+			continue
+		}
+		checked++
+		if (maxAhead == 0 || ahead <= maxAhead) && (maxBehind == 0 || behind <= maxBehind) {
+			continue
+		}
+		results = append(results, Result{
+			Name:    fmt.Sprintf("branch/diverged[%s]", branch),
+			Status:  StatusWarn,
+			Message: fmt.Sprintf("%d ahead, %d behind %s%s", ahead, behind, upstream, divergedLimits(maxAhead, maxBehind)),
+		})
+	}
+	if len(results) == 0 && checked > 0 {
+		return []Result{{
+			Name:    "branch/diverged",
+			Status:  StatusOK,
+			Message: fmt.Sprintf("%d branches close to their upstream", checked),
+		}}
+	}
+	return results
+}
+
+func (c *DivergedCheck) Fix(_ *Repo, results []Result) []Result {
+	return results
+}
+
+// aheadBehind counts the commits branch has that upstream lacks and the
+// reverse. ok is false when either ref is missing, e.g. a gone upstream.
+func aheadBehind(repo *Repo, branch, upstream string) (ahead, behind int, ok bool) {
+	out, err := repo.Git("rev-list", "--left-right", "--count", branch+"..."+upstream)
+	if err != nil {
+		return 0, 0, false
+	}
+	left, right, found := strings.Cut(out, "\t")
+	ahead, err1 := strconv.Atoi(left)
+	behind, err2 := strconv.Atoi(right)
+	return ahead, behind, found && err1 == nil && err2 == nil
+}
+
+// divergedLimits describes the configured limits for the warning message.
+func divergedLimits(maxAhead, maxBehind int) string {
+	var limits []string
+	if maxAhead > 0 {
+		limits = append(limits, fmt.Sprintf("max %d ahead", maxAhead))
+	}
+	if maxBehind > 0 {
+		limits = append(limits, fmt.Sprintf("max %d behind", maxBehind))
+	}
+	return " (" + strings.Join(limits, ", ") + ")"
+}
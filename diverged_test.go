@@ -0,0 +1,48 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDivergedCheck(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("a.txt", "a", "first", time.Now())
+	base := r.git("rev-parse", "HEAD")
+	r.commit("b.txt", "b", "second", time.Now())
+	r.commit("c.txt", "c", "third", time.Now())
+	r.git("remote", "add", "origin", "https://github.com/me/repo.git")
+	r.git("update-ref", "refs/remotes/origin/main", "HEAD")
+	r.git("reset", "--hard", base)
+	r.commit("d.txt", "d", "local", time.Now())
+	r.git("config", "branch.main.remote", "origin")
+	r.git("config", "branch.main.merge", "refs/heads/main")
+	// A branch without upstream and a PR checkout are never reported.
+	r.git("branch", "scratch")
+	r.git("branch", "pr-1")
+	r.git("config", "branch.pr-1.remote", "origin")
+	r.git("config", "branch.pr-1.merge", "refs/pull/1/head")
+
+	if results := (&DivergedCheck{}).Check(r.Repo); len(results) != 0 {
+		t.Errorf("thresholds unset: got %+v, want none", results)
+	}
+
+	r.Config.Thresholds.BranchMaxBehind = 1
+	results := (&DivergedCheck{}).Check(r.Repo)
+	got, ok := resultByName(results, "branch/diverged[main]")
+	if !ok || got.Status != StatusWarn {
+		t.Fatalf("branch/diverged[main] = %+v, want warn", got)
+	}
+	if want := "1 ahead, 2 behind origin/main (max 1 behind)"; got.Message != want {
+		t.Errorf("message = %q, want %q", got.Message, want)
+	}
+	if len(results) != 1 {
+		t.Errorf("got %d results, want only main: %+v", len(results), results)
+	}
+
+	r.Config.Thresholds.BranchMaxBehind = 0
+	r.Config.Thresholds.BranchMaxAhead = 1
+	if got, _ := resultByName((&DivergedCheck{}).Check(r.Repo), "branch/diverged"); got.Status != StatusOK {
+		t.Errorf("within threshold = %+v, want ok", got)
+	}
+}
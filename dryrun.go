@@ -0,0 +1,159 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// The helpers below are the only way fixes change a repo: git commands that
+// mutate go through Git, config writes through SetGitConfig and
+// UnsetGitConfig, and file changes through these methods. With
+// Repo.DryRun set (--fix-dry-run) they change nothing and record what they
+// would have done instead, which takeIntents hands to the caller. Otherwise
+// the file and config helpers note the paths they change for the fix/summary
+// result; see touchedPaths.
+
+// record notes a change that dry-run mode skipped.
+func (r *Repo) record(format string, args ...any) {
+	r.intentsMu.Lock()
+	defer r.intentsMu.Unlock()
+	r.intents = append(r.intents, fmt.Sprintf(format, args...))
+}
+
+// takeIntents returns and clears the changes recorded since the last call.
+func (r *Repo) takeIntents() []string {
+	r.intentsMu.Lock()
+	defer r.intentsMu.Unlock()
+	intents := r.intents
+	r.intents = nil
+	return intents
+}
+
+// touch notes that a fix changed the file at path.
+func (r *Repo) touch(path string) {
+	r.intentsMu.Lock()
+	defer r.intentsMu.Unlock()
+	if r.touched == nil {
+		r.touched = make(map[string]bool)
+	}
+	r.touched[r.displayPath(path)] = true
+}
+
+// touchedPaths returns the files fixes changed, sorted, relative to the
+// repo where possible.
+func (r *Repo) touchedPaths() []string {
+	r.intentsMu.Lock()
+	defer r.intentsMu.Unlock()
+	return slices.Sorted(maps.Keys(r.touched))
+}
+
+// WriteFile replaces the file at path with data, creating its directory.
+func (r *Repo) WriteFile(path string, data []byte) error {
+	if r.DryRun {
+		r.record("write %s", r.displayPath(path))
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return err
+	}
+	r.touch(path)
+	return nil
+}
+
+// AppendFile appends data to the file at path, creating the file and its
+// directory if needed.
+func (r *Repo) AppendFile(path string, data []byte) error {
+	if r.DryRun {
+		r.record("append to %s: %s", r.displayPath(path), strings.Join(strings.Fields(string(data)), " "))
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	r.touch(path)
+	return nil
+}
+
+// RemoveFile removes the file at path. A missing file is not an error.
+func (r *Repo) RemoveFile(path string) error {
+	if _, err := os.Lstat(path); os.IsNotExist(err) {
+		return nil
+	}
+	if r.DryRun {
+		r.record("remove %s", r.displayPath(path))
+		return nil
+	}
+	if err := os.Remove(path); err != nil {
+		return err
+	}
+	r.touch(path)
+	return nil
+}
+
+// Chmod changes the permission bits of the file at path.
+func (r *Repo) Chmod(path string, mode os.FileMode) error {
+	if r.DryRun {
+		r.record("chmod %o %s", mode, r.displayPath(path))
+		return nil
+	}
+	if err := os.Chmod(path, mode); err != nil {
+		return err
+	}
+	r.touch(path)
+	return nil
+}
+
+// displayPath shortens path to be relative to the repo when it is inside it.
+func (r *Repo) displayPath(path string) string {
+	if rel, err := filepath.Rel(r.Dir, path); err == nil && !strings.HasPrefix(rel, "..") {
+		return filepath.ToSlash(rel)
+	}
+	return path
+}
+
+// gitMutates reports whether the git command args changes repo state. It
+// knows the commands fixes use; anything else counts as a read.
+func gitMutates(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	sub := ""
+	if len(args) > 1 {
+		sub = args[1]
+	}
+	switch args[0] {
+	case "remote":
+		return slices.Contains([]string{"add", "rename", "remove", "rm", "set-url", "set-head", "set-branches", "prune"}, sub)
+	case "worktree":
+		return slices.Contains([]string{"add", "remove", "move", "prune", "repair"}, sub)
+	case "reflog":
+		return sub == "expire" || sub == "delete"
+	case "branch", "tag":
+		// Listing forms read; everything fixes use (-D, -m,
+		// --set-upstream-to) writes.
+		return len(args) > 1 && !slices.ContainsFunc(args[1:], func(a string) bool {
+			return a == "--list" || a == "-l" || a == "--show-current"
+		})
+	case "update-index", "update-ref", "rm", "mv", "add", "commit", "reset", "checkout", "switch", "push", "gc", "prune":
+		return true
+	}
+	return false
+}
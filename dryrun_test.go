@@ -0,0 +1,112 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestGitMutates(t *testing.T) {
+	tests := []struct {
+		args []string
+		want bool
+	}{
+		{[]string{"config", "--get", "user.email"}, false},
+		{[]string{"remote", "-v"}, false},
+		{[]string{"remote", "set-url", "origin", "x"}, true},
+		{[]string{"branch", "--list"}, false},
+		{[]string{"branch", "-D", "topic"}, true},
+		{[]string{"branch"}, false},
+		{[]string{"worktree", "list", "--porcelain"}, false},
+		{[]string{"worktree", "remove", "../wt"}, true},
+		{[]string{"reflog", "expire", "--all"}, true},
+		{[]string{"update-index", "--chmod=+x", "run.sh"}, true},
+		{[]string{"rev-parse", "HEAD"}, false},
+	}
+	for _, tt := range tests {
+		if got := gitMutates(tt.args); got != tt.want {
+			t.Errorf("gitMutates(%q) = %v, want %v", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestDryRunProtocolFix(t *testing.T) {
+	r := newTestRepo(t)
+	r.git("remote", "add", "origin", "https://github.com/owner/repo.git")
+	r.Config.Protocol = "ssh"
+	r.reload()
+	r.DryRun = true
+
+	check := &ProtocolCheck{}
+	results := check.Check(r.Repo)
+	r.takeIntents()
+	fixed := dryRunResults(check.Fix(r.Repo, results), r.takeIntents())
+
+	got, _ := resultByName(fixed, "remote/protocol[origin]")
+	if got.Status != StatusFix || !strings.HasPrefix(got.Message, "would have ") {
+		t.Fatalf("dry-run fix = %+v, want \"would have ...\" fix", got)
+	}
+	want := "git remote set-url origin git@github.com:owner/repo.git"
+	if !slices.Contains(got.Details, want) {
+		t.Errorf("details = %q, want %q", got.Details, want)
+	}
+	if url := r.git("remote", "get-url", "origin"); url != "https://github.com/owner/repo.git" {
+		t.Errorf("origin url = %q, want it unchanged", url)
+	}
+}
+
+func TestDryRunFileHelpers(t *testing.T) {
+	r := newTestRepo(t)
+	r.DryRun = true
+	path := filepath.Join(r.Dir, "notes", "a.txt")
+
+	if err := r.WriteFile(path, []byte("x\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.RemoveFile(filepath.Join(r.Dir, "missing")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Dir(path)); !os.IsNotExist(err) {
+		t.Errorf("dry-run WriteFile created %s", filepath.Dir(path))
+	}
+	if got := r.takeIntents(); !slices.Equal(got, []string{"write notes/a.txt"}) {
+		t.Errorf("intents = %q, want [write notes/a.txt]", got)
+	}
+}
+
+func TestTouchedPathsOnlyOnSuccess(t *testing.T) {
+	r := newTestRepo(t)
+	path := filepath.Join(r.Dir, "a.txt")
+	if err := r.WriteFile(path, []byte("x\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Chmod(filepath.Join(r.Dir, "missing"), 0o755); err == nil {
+		t.Fatal("Chmod of a missing file succeeded")
+	}
+	if err := r.UnsetGitConfig("lint.nothing"); err == nil {
+		t.Fatal("UnsetGitConfig of an unset key succeeded")
+	}
+	if got := r.touchedPaths(); !slices.Equal(got, []string{"a.txt"}) {
+		t.Errorf("touched = %q, want [a.txt]", got)
+	}
+}
+
+func TestDryRunResultsSplitsIntents(t *testing.T) {
+	results := []Result{
+		{Name: "branch/merged[a]", Status: StatusFix, Message: "deleted a"},
+		{Name: "branch/merged[b]", Status: StatusFix, Message: "deleted b"},
+		{Name: "branch/gone[c]", Status: StatusWarn, Message: "upstream gone"},
+	}
+	out := dryRunResults(results, []string{"git branch -D a", "git branch -D b"})
+	if out[0].Message != "would have deleted a" {
+		t.Errorf("message = %q", out[0].Message)
+	}
+	if !slices.Equal(out[1].Details, []string{"git branch -D b"}) {
+		t.Errorf("details for b = %q", out[1].Details)
+	}
+	if out[2].Message != "upstream gone" {
+		t.Errorf("non-fix result changed: %+v", out[2])
+	}
+}
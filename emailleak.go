@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// defaultEmailLeakCommits is how many recent commits EmailLeakCheck scans
+// when emailLeakCommits is unset.
+const defaultEmailLeakCommits = 50
+
+// EmailLeakCheck is the history side of the personal-repo email rule: it
+// scans the most recent commits of a personal repo for ones authored with
+// the work email, which would publish it with the repo. Rewriting history is
+// out of scope, so the check only warns.
+type EmailLeakCheck struct{}
+
+func (c *EmailLeakCheck) Name() string { return "email-leak" }
+
+func (c *EmailLeakCheck) Check(repo *Repo) []Result {
+	workEmail := repo.Config.Identity.WorkEmail
+	if repo.Work || workEmail == "" || strings.EqualFold(workEmail, repo.Config.Identity.PersonalEmail) {
+		return nil
+	}
+	n := repo.Config.Thresholds.EmailLeakCommits
+	if n == 0 {
+		n = defaultEmailLeakCommits
+	}
+	rev := "HEAD"
+	if repo.Ref != "" {
+		rev = repo.Ref
+	}
+	out, err := repo.Git("log", "--max-count="+strconv.Itoa(n), "--format=%h%x00%ae%x00%s", rev)
+	if err != nil || out == "" {
+		return nil
+	}
+
+	var details []string
+	for _, line := range strings.Split(out, "\n") {
+		f := strings.Split(line, "\x00")
+		if len(f) == 3 && strings.EqualFold(f[1], workEmail) {
+			details = append(details, f[0]+" "+f[2])
+		}
+	}
+	if len(details) > 0 {
+		return []Result{{
+			Name:    "identity/email-leak",
+			Status:  StatusWarn,
+			Message: fmt.Sprintf("%d of the last %d commits are authored with work email %s", len(details), n, workEmail),
+			Details: details,
+		}}
+	}
+	return []Result{{
+		Name:    "identity/email-leak",
+		Status:  StatusOK,
+		Message: fmt.Sprintf("work email not used in the last %d commits", n),
+	}}
+}
+
+func (c *EmailLeakCheck) Fix(_ *Repo, results []Result) []Result {
+	return results
+}
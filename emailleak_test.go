@@ -0,0 +1,40 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEmailLeakCheck(t *testing.T) {
+	r := newTestRepo(t)
+	r.Config.Identity.WorkEmail = "test@work.example"
+	now := time.Now()
+	r.commit("a.txt", "a", "personal", now)
+
+	if got, _ := resultByName((&EmailLeakCheck{}).Check(r.Repo), "identity/email-leak"); got.Status != StatusOK {
+		t.Fatalf("identity/email-leak = %+v, want ok", got)
+	}
+
+	r.commitAs("b.txt", "b", "leaked", "Test User", "Test@Work.example", now)
+	r.commit("c.txt", "c", "personal again", now)
+	got, ok := resultByName((&EmailLeakCheck{}).Check(r.Repo), "identity/email-leak")
+	if !ok || got.Status != StatusWarn || got.Fixable {
+		t.Fatalf("identity/email-leak = %+v, want non-fixable warn", got)
+	}
+	if len(got.Details) != 1 || !strings.HasSuffix(got.Details[0], " leaked") {
+		t.Errorf("details = %q, want the leaked commit", got.Details)
+	}
+
+	// Only the most recent commits are scanned.
+	r.Config.Thresholds.EmailLeakCommits = 1
+	if got, _ := resultByName((&EmailLeakCheck{}).Check(r.Repo), "identity/email-leak"); got.Status != StatusOK {
+		t.Errorf("with 1 commit scanned: %+v, want ok", got)
+	}
+
+	// Work repos are expected to use the work email.
+	r.Work = true
+	if results := (&EmailLeakCheck{}).Check(r.Repo); results != nil {
+		t.Errorf("work repo: got %+v, want none", results)
+	}
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ExecBitCheck standardizes executable bits on tracked files: files matching
+// the executableFiles config must be executable and all other regular files
+// must not be. The check does nothing when the list is empty. The fix stages
+// the corrected modes with git update-index --chmod and updates the working
+// tree to match; committing them is left to the user.
+type ExecBitCheck struct{}
+
+func (c *ExecBitCheck) Name() string { return "exec-bit" }
+
+func (c *ExecBitCheck) Check(repo *Repo) []Result {
+	if len(repo.Config.ExecutableFiles) == 0 {
+		return nil
+	}
+	violations, err := execBitViolations(repo)
+	if err != nil {
+		return nil
+	}
+	if len(violations) > 0 {
+		var details []string
+		for _, v := range violations {
+			details = append(details, v.String())
+		}
+		return []Result{{
+			Name:    "files/exec-bit",
+			Status:  StatusWarn,
+			Message: fmt.Sprintf("%d tracked files have the wrong executable bit", len(violations)),
+			Details: details,
+			Fixable: repo.Ref == "",
+		}}
+	}
+	return []Result{{
+		Name:    "files/exec-bit",
+		Status:  StatusOK,
+		Message: "executable bits match executableFiles",
+	}}
+}
+
+func (c *ExecBitCheck) Fix(repo *Repo, results []Result) []Result {
+	var fixed []Result
+	for _, r := range results {
+		if !r.Fixable || r.Name != "files/exec-bit" {
+			fixed = append(fixed, r)
+			continue
+		}
+		violations, err := execBitViolations(repo)
+		if err != nil {
+			fixed = append(fixed, r)
+			continue
+		}
+		var failed []string
+		for _, v := range violations {
+			if err := v.fix(repo); err != nil {
+				failed = append(failed, fmt.Sprintf("%s: %v", v.path, err))
+			}
+		}
+		if len(failed) > 0 {
+			fixed = append(fixed, Result{
+				Name:    r.Name,
+				Status:  StatusWarn,
+				Message: fmt.Sprintf("could not fix %d of %d files", len(failed), len(violations)),
+				Details: failed,
+			})
+			continue
+		}
+		fixed = append(fixed, Result{
+			Name:    r.Name,
+			Status:  StatusFix,
+			Message: fmt.Sprintf("staged mode changes for %d files; commit them", len(violations)),
+		})
+	}
+	return fixed
+}
+
+// execBitViolation is a tracked file whose executable bit disagrees with the
+// executableFiles config.
+type execBitViolation struct {
+	path       string
+	executable bool // what the file should be
+}
+
+func (v execBitViolation) String() string {
+	if v.executable {
+		return v.path + " (should be executable)"
+	}
+	return v.path + " (should not be executable)"
+}
+
+// fix stages the wanted mode and applies it to the working tree file.
+func (v execBitViolation) fix(repo *Repo) error {
+	flag := "--chmod=-x"
+	if v.executable {
+		flag = "--chmod=+x"
+	}
+	if _, err := repo.Git("update-index", flag, "--", v.path); err != nil {
+		return err
+	}
+	file := filepath.Join(repo.Dir, filepath.FromSlash(v.path))
+	info, err := os.Stat(file)
+	if err != nil {
+		return nil // deleted in the working tree; the index is what counts
+	}
+	mode := info.Mode().Perm() &^ 0o111
+	if v.executable {
+		mode |= 0o111 & (mode >> 2) // +x wherever read is allowed
+	}
+	return repo.Chmod(file, mode)
+}
+
+// execBitViolations lists the regular files in the index (or in the --ref
+// tree) whose mode disagrees with the executableFiles config.
+func execBitViolations(repo *Repo) ([]execBitViolation, error) {
+	var out string
+	var err error
+	if repo.Ref != "" {
+		out, err = repo.Git("ls-tree", "-r", repo.Ref)
+	} else {
+		out, err = repo.Git("ls-files", "-s")
+	}
+	if err != nil {
+		return nil, err
+	}
+	var violations []execBitViolation
+	for _, line := range strings.Split(out, "\n") {
+		// Format: <mode> <object> <stage>\t<path> (ls-files) or
+		// <mode> <type> <object>\t<path> (ls-tree).
+		meta, file, ok := strings.Cut(line, "\t")
+		if !ok {
+			continue
+		}
+		mode, _, _ := strings.Cut(meta, " ")
+		if mode != "100644" && mode != "100755" {
+			continue // symlinks, submodules
+		}
+		want := pathMatchesAny(file, repo.Config.ExecutableFiles)
+		if want != (mode == "100755") {
+			violations = append(violations, execBitViolation{path: file, executable: want})
+		}
+	}
+	return violations, nil
+}
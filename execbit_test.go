@@ -0,0 +1,45 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestExecBitCheck(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("build.sh", "#!/bin/sh\n", "script without +x", time.Now())
+	r.commit("data.txt", "data", "data file", time.Now())
+	r.git("update-index", "--chmod=+x", "data.txt")
+	r.git("commit", "-q", "-m", "data file with +x")
+
+	if results := (&ExecBitCheck{}).Check(r.Repo); len(results) != 0 {
+		t.Errorf("executableFiles unset: got %+v, want none", results)
+	}
+
+	r.Config.ExecutableFiles = []string{"*.sh"}
+	results := (&ExecBitCheck{}).Check(r.Repo)
+	got, _ := resultByName(results, "files/exec-bit")
+	if got.Status != StatusWarn || !got.Fixable || len(got.Details) != 2 {
+		t.Fatalf("exec-bit = %+v, want fixable warn for build.sh and data.txt", got)
+	}
+
+	fixed := (&ExecBitCheck{}).Fix(r.Repo, results)
+	if got, _ := resultByName(fixed, "files/exec-bit"); got.Status != StatusFix {
+		t.Errorf("after fix = %+v, want fix", got)
+	}
+	if got, _ := resultByName((&ExecBitCheck{}).Check(r.Repo), "files/exec-bit"); got.Status != StatusOK {
+		t.Errorf("re-check = %+v, want ok", got)
+	}
+	info, err := os.Stat(filepath.Join(r.dir, "build.sh"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm()&0o100 == 0 {
+		t.Errorf("build.sh mode = %v, want executable in the working tree", info.Mode())
+	}
+	if out := r.git("diff", "--name-only"); out != "" {
+		t.Errorf("working tree differs from the index after fix: %q", out)
+	}
+}
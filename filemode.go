@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// fileModeMinChanges is how many mode-only changes it takes before
+// FileModeCheck blames core.fileMode rather than a deliberate chmod.
+const fileModeMinChanges = 5
+
+// FileModeCheck detects the "everything is modified" symptom of
+// core.fileMode=true on a filesystem that doesn't preserve executable bits:
+// many files whose only change is their mode. The fix sets core.fileMode to
+// false locally.
+type FileModeCheck struct{}
+
+func (c *FileModeCheck) Name() string { return "filemode" }
+
+func (c *FileModeCheck) Check(repo *Repo) []Result {
+	if repo.Ref != "" {
+		return refNotApplicable("config/filemode")
+	}
+	if mode, _ := repo.Git("config", "--type=bool", "--get", "core.fileMode"); mode == "false" {
+		return nil
+	}
+	modeOnly := modeOnlyChanges(repo)
+	if len(modeOnly) < fileModeMinChanges {
+		return nil
+	}
+	return []Result{{
+		Name:    "config/filemode",
+		Status:  StatusWarn,
+		Message: fmt.Sprintf("%d files differ only in mode; filesystem may not preserve exec bits", len(modeOnly)),
+		Details: modeOnly,
+		Fixable: true,
+	}}
+}
+
+func (c *FileModeCheck) Fix(repo *Repo, results []Result) []Result {
+	var fixed []Result
+	for _, r := range results {
+		if !r.Fixable || r.Name != "config/filemode" {
+			fixed = append(fixed, r)
+			continue
+		}
+		if err := repo.SetGitConfig("core.fileMode", "false"); err != nil {
+			fixed = append(fixed, r)
+			continue
+		}
+		fixed = append(fixed, Result{
+			Name:    r.Name,
+			Status:  StatusFix,
+			Message: "set core.fileMode to false",
+		})
+	}
+	return fixed
+}
+
+// modeOnlyChanges returns the unstaged files whose content matches the index
+// but whose mode differs. It reads raw diff output, where a mode-only change
+// keeps the same object ID on both sides.
+func modeOnlyChanges(repo *Repo) []string {
+	out, err := repo.Git("diff", "--raw", "--no-abbrev")
+	if err != nil || out == "" {
+		return nil
+	}
+	var files []string
+	for _, line := range strings.Split(out, "\n") {
+		// Format: :<old mode> <new mode> <old sha> <new sha> <status>\t<path>
+		meta, path, ok := strings.Cut(line, "\t")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(strings.TrimPrefix(meta, ":"))
+		if len(fields) < 5 || fields[0] == fields[1] {
+			continue
+		}
+		// The worktree side shows as all zeros when git hasn't hashed the
+		// file yet; hash it to compare content.
+		newOID := fields[3]
+		if isZeroOID(newOID) {
+			newOID, _ = repo.Git("hash-object", "--", path)
+		}
+		if newOID != fields[2] {
+			continue
+		}
+		files = append(files, path)
+	}
+	return files
+}
+
+func isZeroOID(oid string) bool {
+	return strings.Trim(oid, "0") == ""
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileModeCheckModeOnlyChanges(t *testing.T) {
+	r := newTestRepo(t)
+	r.git("config", "core.fileMode", "true")
+	now := time.Now()
+	for i := range fileModeMinChanges {
+		r.commit(fmt.Sprintf("script%d.sh", i), "echo hi\n", "add script", now)
+	}
+	if results := (&FileModeCheck{}).Check(r.Repo); len(results) != 0 {
+		t.Fatalf("clean tree: got %+v, want none", results)
+	}
+
+	for i := range fileModeMinChanges {
+		if err := os.Chmod(filepath.Join(r.dir, fmt.Sprintf("script%d.sh", i)), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	results := (&FileModeCheck{}).Check(r.Repo)
+	got, ok := resultByName(results, "config/filemode")
+	if !ok || got.Status != StatusWarn || !got.Fixable || len(got.Details) != fileModeMinChanges {
+		t.Fatalf("config/filemode = %+v, want fixable warn with %d details", got, fileModeMinChanges)
+	}
+
+	(&FileModeCheck{}).Fix(r.Repo, results)
+	if v := r.Repo.GitConfig("core.fileMode"); v != "false" {
+		t.Errorf("core.fileMode after fix = %q, want false", v)
+	}
+	if results := (&FileModeCheck{}).Check(r.Repo); len(results) != 0 {
+		t.Errorf("after fix: got %+v, want none", results)
+	}
+}
+
+func TestFileModeCheckIgnoresContentChanges(t *testing.T) {
+	r := newTestRepo(t)
+	r.git("config", "core.fileMode", "true")
+	now := time.Now()
+	for i := range fileModeMinChanges {
+		name := fmt.Sprintf("script%d.sh", i)
+		r.commit(name, "echo hi\n", "add script", now)
+		path := filepath.Join(r.dir, name)
+		if err := os.WriteFile(path, []byte("echo bye\n"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chmod(path, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if results := (&FileModeCheck{}).Check(r.Repo); len(results) != 0 {
+		t.Errorf("content changes: got %+v, want none", results)
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// repoAge is a scanned repo and the committer date of its HEAD, for the
+// fleet staleness summary in recursive mode.
+type repoAge struct {
+	Name       string    `json:"name"`
+	LastCommit time.Time `json:"last_commit"`
+}
+
+// headCommitTime returns the committer date of HEAD in dir.
+func headCommitTime(ctx context.Context, dir string) (time.Time, bool) {
+	out, err := gitInDir(ctx, dir, "log", "-1", "--format=%ct", "HEAD")
+	if err != nil {
+		return time.Time{}, false
+	}
+	secs, err := strconv.ParseInt(out, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(secs, 0), true
+}
+
+// fleetOutliers returns the repos whose HEAD is more than gap older than the
+// median HEAD across all of them, oldest first, along with that median. A
+// repo that is old on its own says little; one far behind its siblings is
+// likely forgotten.
+func fleetOutliers(ages []repoAge, gap time.Duration) ([]repoAge, time.Time) {
+	if len(ages) == 0 {
+		return nil, time.Time{}
+	}
+	sorted := slices.Clone(ages)
+	slices.SortFunc(sorted, func(a, b repoAge) int { return a.LastCommit.Compare(b.LastCommit) })
+	median := sorted[len(sorted)/2].LastCommit
+
+	var outliers []repoAge
+	for _, a := range sorted {
+		if median.Sub(a.LastCommit) > gap {
+			outliers = append(outliers, a)
+		}
+	}
+	return outliers, median
+}
+
+// printFleetSummary writes the stalest-repos section for text output.
+func printFleetSummary(w io.Writer, outliers []repoAge, median time.Time) {
+	fmt.Fprintf(w, "\n=== stalest repos (median last commit %s) ===\n", median.Format("2006-01-02"))
+	for _, a := range outliers {
+		fmt.Fprintf(w, "%s: last commit %s (%s before median)\n",
+			a.Name, a.LastCommit.Format("2006-01-02"), formatDuration(median.Sub(a.LastCommit)))
+	}
+}
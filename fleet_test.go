@@ -0,0 +1,37 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFleetOutliers(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	ages := []repoAge{
+		{Name: "active", LastCommit: now},
+		{Name: "recent", LastCommit: now.Add(-3 * day)},
+		{Name: "median", LastCommit: now.Add(-5 * day)},
+		{Name: "forgotten", LastCommit: now.Add(-400 * day)},
+		{Name: "old", LastCommit: now.Add(-100 * day)},
+	}
+	outliers, median := fleetOutliers(ages, 90*day)
+	if !median.Equal(now.Add(-5 * day)) {
+		t.Errorf("median = %v, want 5 days ago", median)
+	}
+	if len(outliers) != 2 || outliers[0].Name != "forgotten" || outliers[1].Name != "old" {
+		t.Fatalf("outliers = %+v, want forgotten then old", outliers)
+	}
+
+	var buf bytes.Buffer
+	printFleetSummary(&buf, outliers, median)
+	if !strings.Contains(buf.String(), "forgotten: last commit 2023-04-28 (395d before median)") {
+		t.Errorf("summary = %q", buf.String())
+	}
+
+	if got, _ := fleetOutliers(nil, day); got != nil {
+		t.Errorf("no repos: outliers = %+v, want none", got)
+	}
+}
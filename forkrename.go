@@ -0,0 +1,164 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultForkParentTTL is how long a cached fork parent is trusted before
+// ForkParent looks it up again or ForkRenameCheck verifies it.
+const defaultForkParentTTL = 30 * 24 * time.Hour
+
+// ForkRenameCheck detects a fork parent that was renamed or moved on GitHub.
+// GitHub redirects the old name, so remotes keep working while
+// remote.origin.gh-parent and the upstream URL go stale. The cached parent
+// is re-verified at most once per thresholds.forkParentTTL; the check only
+// runs when the online config option is set.
+type ForkRenameCheck struct{}
+
+func (c *ForkRenameCheck) Name() string { return "fork-rename" }
+
+func (c *ForkRenameCheck) Check(repo *Repo) []Result {
+	if !repo.Config.Online {
+		return nil
+	}
+	cached := repo.GitConfig("remote.origin.gh-parent")
+	if cached == "" || cached == "none" {
+		return nil
+	}
+	if !forkParentCheckDue(repo, time.Now()) {
+		return nil
+	}
+
+	owner, repoName := parseGitHubRepo(repo.RemoteURL("origin"))
+	if owner == "" {
+		return nil
+	}
+	fresh, ok := ghForkParent(repo.Context(), owner, repoName)
+	if !ok {
+		return nil
+	}
+	if strings.EqualFold(fresh, cached) {
+		markForkParentChecked(repo, time.Now())
+		return []Result{{
+			Name:    "remote/fork-parent",
+			Status:  StatusOK,
+			Message: fmt.Sprintf("fork parent %s is current", cached),
+		}}
+	}
+
+	msg := fmt.Sprintf("cached fork parent %s is now %s", cached, fresh)
+	if fresh == "" {
+		msg = fmt.Sprintf("cached fork parent %s, but origin is no longer a fork", cached)
+	}
+	return []Result{{
+		Name:    "remote/fork-parent",
+		Status:  StatusWarn,
+		Message: msg,
+		Details: remotesForRepo(repo, cached),
+		Fixable: true,
+	}}
+}
+
+// Fix refreshes the cached parent and re-points remotes that still use the
+// old parent's name at the new one, keeping each remote's protocol.
+func (c *ForkRenameCheck) Fix(repo *Repo, results []Result) []Result {
+	var fixed []Result
+	for _, r := range results {
+		if !r.Fixable || r.Name != "remote/fork-parent" {
+			fixed = append(fixed, r)
+			continue
+		}
+		owner, repoName := parseGitHubRepo(repo.RemoteURL("origin"))
+		fresh, ok := ghForkParent(repo.Context(), owner, repoName)
+		if owner == "" || !ok {
+			fixed = append(fixed, r)
+			continue
+		}
+
+		cached := repo.GitConfig("remote.origin.gh-parent")
+		var repointed []string
+		failed := false
+		if fresh != "" {
+			newOwner, newRepo, _ := strings.Cut(fresh, "/")
+			for _, name := range remotesForRepo(repo, cached) {
+				url := repo.RemoteURL(name)
+				protocol := urlProtocol(url)
+				if protocol == "" {
+					protocol = "https"
+				}
+				if _, err := repo.Git("remote", "set-url", name, githubCloneURL(newOwner, newRepo, protocol)); err != nil {
+					failed = true
+					continue
+				}
+				repointed = append(repointed, name)
+			}
+		}
+
+		value := fresh
+		if value == "" {
+			value = "none"
+		}
+		if failed || repo.SetGitConfig("remote.origin.gh-parent", value) != nil {
+			fixed = append(fixed, r)
+			continue
+		}
+		markForkParentChecked(repo, time.Now())
+
+		msg := fmt.Sprintf("updated cached fork parent to %s", value)
+		if len(repointed) > 0 {
+			msg += fmt.Sprintf(", re-pointed %s", strings.Join(repointed, ", "))
+		}
+		fixed = append(fixed, Result{
+			Name:    r.Name,
+			Status:  StatusFix,
+			Message: msg,
+		})
+	}
+	return fixed
+}
+
+// forkParentCheckDue reports whether the cached fork parent was last
+// verified longer ago than the configured TTL (or never), or --refresh-cache
+// asks to verify it now.
+func forkParentCheckDue(repo *Repo, now time.Time) bool {
+	if repo.RefreshCache {
+		return true
+	}
+	return forkParentExpired(repo, now)
+}
+
+// forkParentExpired reports whether remote.origin.gh-parent-checked is older
+// than thresholds.forkParentTTL, or missing.
+func forkParentExpired(repo *Repo, now time.Time) bool {
+	ttl := repo.Config.Thresholds.ForkParentTTL.Duration
+	if ttl == 0 {
+		ttl = defaultForkParentTTL
+	}
+	checked, err := strconv.ParseInt(repo.GitConfig("remote.origin.gh-parent-checked"), 10, 64)
+	if err != nil {
+		return true
+	}
+	return now.Sub(time.Unix(checked, 0)) > ttl
+}
+
+// markForkParentChecked records when the cached fork parent was verified.
+func markForkParentChecked(repo *Repo, now time.Time) {
+	repo.SetGitConfig("remote.origin.gh-parent-checked", strconv.FormatInt(now.Unix(), 10))
+}
+
+// remotesForRepo returns the remotes whose GitHub URL names the given
+// "owner/repo", compared case-insensitively.
+func remotesForRepo(repo *Repo, fullName string) []string {
+	remotes, _ := repo.Remotes()
+	var names []string
+	for _, name := range remotes {
+		owner, repoName := parseGitHubRepo(repo.RemoteURL(name))
+		if owner != "" && strings.EqualFold(owner+"/"+repoName, fullName) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
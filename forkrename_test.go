@@ -0,0 +1,44 @@
+package main
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestForkParentCheckDue(t *testing.T) {
+	r := newTestRepo(t)
+	now := time.Now()
+	if !forkParentCheckDue(r.Repo, now) {
+		t.Error("never-checked parent should be due")
+	}
+
+	markForkParentChecked(r.Repo, now.Add(-time.Hour))
+	if forkParentCheckDue(r.Repo, now) {
+		t.Error("parent checked an hour ago should not be due with the default TTL")
+	}
+
+	r.Config.Thresholds.ForkParentTTL = Duration{30 * time.Minute}
+	if !forkParentCheckDue(r.Repo, now) {
+		t.Error("parent checked an hour ago should be due with a 30m TTL")
+	}
+}
+
+func TestForkRenameCheckSkipsWithinTTL(t *testing.T) {
+	r := forkRepo(t)
+	r.git("config", "remote.origin.gh-parent", "acme/repo")
+	r.git("config", "remote.origin.gh-parent-checked", strconv.FormatInt(time.Now().Unix(), 10))
+	r.Config.Online = true
+
+	if results := (&ForkRenameCheck{}).Check(r.Repo); len(results) != 0 {
+		t.Errorf("recently verified parent: got %+v, want none", results)
+	}
+}
+
+func TestRemotesForRepo(t *testing.T) {
+	r := forkRepo(t)
+	got := remotesForRepo(r.Repo, "Acme/Repo")
+	if len(got) != 1 || got[0] != "upstream" {
+		t.Errorf("remotesForRepo = %v, want [upstream]", got)
+	}
+}
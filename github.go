@@ -1,8 +1,9 @@
 package main
 
 import (
-	"os/exec"
+	"context"
 	"strings"
+	"time"
 )
 
 // parseGitHubRepo extracts owner and repo from a GitHub URL or bare "owner/repo" slug.
@@ -27,84 +28,152 @@ func parseGitHubRepo(url string) (owner, repo string) {
 	return parts[0], strings.TrimSuffix(parts[1], ".git")
 }
 
+// parseRemoteURL splits an http(s) (https://<host>/<path>) or scp-like ssh
+// (git@<host>:<path>) remote URL into host and path, for any host. Returns
+// "", "" for other forms, such as local paths or ssh:// URLs.
+func parseRemoteURL(url string) (host, path string) {
+	if rest, ok := strings.CutPrefix(url, "https://"); ok {
+		host, path, _ = strings.Cut(rest, "/")
+	} else if rest, ok := strings.CutPrefix(url, "http://"); ok {
+		host, path, _ = strings.Cut(rest, "/")
+	} else if rest, ok := strings.CutPrefix(url, "git@"); ok {
+		host, path, _ = strings.Cut(rest, ":")
+	}
+	if host == "" || path == "" || strings.ContainsAny(host, "@/") {
+		return "", ""
+	}
+	return host, path
+}
+
 // ghForkParent queries the GitHub API for the fork parent of owner/repo.
 // Returns (parent, true) on success: parent is "owner/repo" or "" if not a fork.
 // Returns ("", false) on any error (no gh CLI, network, 404, private repo).
-func ghForkParent(owner, repo string) (parent string, ok bool) {
-	cmd := exec.Command("gh", "api", "repos/"+owner+"/"+repo, "--jq", `.parent.full_name // empty`)
-	out, err := cmd.Output()
+func ghForkParent(ctx context.Context, owner, repo string) (parent string, ok bool) {
+	out, err := runCommand(ctx, "", "gh", "api", "repos/"+owner+"/"+repo, "--jq", `.parent.full_name // empty`)
 	if err != nil {
 		return "", false
 	}
-	return strings.TrimSpace(string(out)), true
+	return strings.TrimSpace(out), true
 }
 
 // ghPRState returns the state of a pull request: "merged", "closed", or "open".
 // Returns ("", false) on any error.
-func ghPRState(owner, repo, number string) (string, bool) {
-	out, err := exec.Command("gh", "api",
+func ghPRState(ctx context.Context, owner, repo, number string) (string, bool) {
+	out, err := runCommand(ctx, "", "gh", "api",
 		"repos/"+owner+"/"+repo+"/pulls/"+number,
-		"--jq", `if .merged then "merged" else .state end`).Output()
+		"--jq", `if .merged then "merged" else .state end`)
 	if err != nil {
 		return "", false
 	}
-	return strings.TrimSpace(string(out)), true
+	return strings.TrimSpace(out), true
 }
 
 // ghCommitInMergedPR reports whether the commit SHA belongs to any merged
 // PR in owner/repo. Returns (false, false) on any error so callers can
 // conservatively treat unknown as "not safe".
-func ghCommitInMergedPR(owner, repo, sha string) (inMerged bool, ok bool) {
-	out, err := exec.Command("gh", "api",
+func ghCommitInMergedPR(ctx context.Context, owner, repo, sha string) (inMerged bool, ok bool) {
+	out, err := runCommand(ctx, "", "gh", "api",
 		"repos/"+owner+"/"+repo+"/commits/"+sha+"/pulls",
-		"--jq", `[.[] | select(.merged_at != null)] | length`).Output()
+		"--jq", `[.[] | select(.merged_at != null)] | length`)
 	if err != nil {
 		return false, false
 	}
-	n := strings.TrimSpace(string(out))
+	n := strings.TrimSpace(out)
 	return n != "" && n != "0", true
 }
 
 // ghRepoPrivate queries the GitHub API to check if owner/repo is private.
 // Returns (private, true) on success, or (false, false) on any error.
-func ghRepoPrivate(owner, repo string) (private bool, ok bool) {
-	cmd := exec.Command("gh", "api", "repos/"+owner+"/"+repo, "--jq", `.private`)
-	out, err := cmd.Output()
+func ghRepoPrivate(ctx context.Context, owner, repo string) (private bool, ok bool) {
+	out, err := runCommand(ctx, "", "gh", "api", "repos/"+owner+"/"+repo, "--jq", `.private`)
 	if err != nil {
 		return false, false
 	}
-	return strings.TrimSpace(string(out)) == "true", true
+	return strings.TrimSpace(out) == "true", true
+}
+
+// ghRepoReadOnly queries the GitHub API for whether owner/repo is archived
+// or disabled. Returns ("archived" or "disabled" or "", true) on success, or
+// ("", false) on any error.
+func ghRepoReadOnly(ctx context.Context, owner, repo string) (state string, ok bool) {
+	out, err := runCommand(ctx, "", "gh", "api", "repos/"+owner+"/"+repo, "--jq", `.archived, .disabled`)
+	if err != nil {
+		return "", false
+	}
+	fields := strings.Fields(out)
+	if len(fields) != 2 {
+		return "", false
+	}
+	switch {
+	case fields[0] == "true":
+		return "archived", true
+	case fields[1] == "true":
+		return "disabled", true
+	}
+	return "", true
 }
 
 // ForkParent returns the "owner/repo" of origin's fork parent on GitHub.
-// Caches the result in remote.origin.gh-parent to avoid repeated API calls.
-// Returns "" if origin is not a GitHub fork or if the lookup fails transiently.
+// Caches the result in remote.origin.gh-parent to avoid repeated API calls,
+// and looks it up again once the cache is older than
+// thresholds.forkParentTTL or with --refresh-cache (see
+// forkParentRefreshDue). Returns "" if origin is not a GitHub fork or if the
+// lookup fails transiently; a failed refresh keeps the cached value.
 func (r *Repo) ForkParent() string {
+	r.cacheMu.Lock()
+	defer r.cacheMu.Unlock()
 	cached := r.GitConfig("remote.origin.gh-parent")
-	if cached == "none" {
-		return ""
-	}
-	if cached != "" {
-		return cached
+	if cached != "" && !r.forkParentRefreshDue(cached, time.Now()) {
+		return cachedParent(cached)
 	}
 
 	owner, repo := parseGitHubRepo(r.RemoteURL("origin"))
 	if owner == "" {
-		return ""
+		return cachedParent(cached)
 	}
 
-	parent, ok := ghForkParent(owner, repo)
+	r.forkParentRefreshed = true
+	parent, ok := ghForkParent(r.Context(), owner, repo)
 	if !ok {
-		return ""
+		return cachedParent(cached)
 	}
-	if parent == "" {
-		r.SetGitConfig("remote.origin.gh-parent", "none")
-		return ""
+	value := parent
+	if value == "" {
+		value = "none"
 	}
-	r.SetGitConfig("remote.origin.gh-parent", parent)
+	r.SetGitConfig("remote.origin.gh-parent", value)
+	markForkParentChecked(r, time.Now())
 	return parent
 }
 
+// cachedParent turns a remote.origin.gh-parent value into ForkParent's
+// result: the "none" sentinel means origin is not a fork.
+func cachedParent(cached string) string {
+	if cached == "none" {
+		return ""
+	}
+	return cached
+}
+
+// forkParentRefreshDue reports whether ForkParent should look up the cached
+// value again: with --refresh-cache, or when it was last checked longer ago
+// than thresholds.forkParentTTL (or never). The lookup happens at most
+// once per run. With online set, a cached parent (not "none") is left to
+// ForkRenameCheck, which verifies it more often and reports a rename
+// instead of absorbing it into the cache.
+func (r *Repo) forkParentRefreshDue(cached string, now time.Time) bool {
+	if r.forkParentRefreshed {
+		return false
+	}
+	if cached != "none" && r.Config.Online {
+		return false
+	}
+	if r.RefreshCache {
+		return true
+	}
+	return forkParentExpired(r, now)
+}
+
 // ForkParentRemote returns the remote name whose GitHub owner/repo matches
 // origin's fork parent. Returns "" if no matching remote is found.
 func (r *Repo) ForkParentRemote() string {
@@ -127,3 +196,35 @@ func (r *Repo) ForkParentRemote() string {
 	}
 	return ""
 }
+
+// OriginReadOnly returns "archived" or "disabled" when origin's GitHub repo
+// no longer accepts pushes, or "" otherwise. Caches the result in
+// remote.origin.gh-archived like ForkParent. Returns "" if origin is not on
+// GitHub or if the lookup fails transiently.
+func (r *Repo) OriginReadOnly() string {
+	r.cacheMu.Lock()
+	defer r.cacheMu.Unlock()
+	cached := r.GitConfig("remote.origin.gh-archived")
+	if cached == "none" {
+		return ""
+	}
+	if cached != "" {
+		return cached
+	}
+
+	owner, repo := parseGitHubRepo(r.RemoteURL("origin"))
+	if owner == "" {
+		return ""
+	}
+
+	state, ok := ghRepoReadOnly(r.Context(), owner, repo)
+	if !ok {
+		return ""
+	}
+	if state == "" {
+		r.SetGitConfig("remote.origin.gh-archived", "none")
+		return ""
+	}
+	r.SetGitConfig("remote.origin.gh-archived", state)
+	return state
+}
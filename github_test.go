@@ -1,6 +1,14 @@
 package main
 
-import "testing"
+import (
+	"context"
+	"errors"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
 
 func TestParseGitHubRepo(t *testing.T) {
 	tests := []struct {
@@ -29,6 +37,29 @@ func TestParseGitHubRepo(t *testing.T) {
 	}
 }
 
+func TestParseRemoteURL(t *testing.T) {
+	tests := []struct {
+		in       string
+		wantHost string
+		wantPath string
+	}{
+		{"https://github.com/owner/repo.git", "github.com", "owner/repo.git"},
+		{"git@gitlab.com:group/sub/repo.git", "gitlab.com", "group/sub/repo.git"},
+		{"https://bitbucket.org/owner/repo", "bitbucket.org", "owner/repo"},
+		{"https://user@host.example/repo.git", "", ""},
+		{"ssh://git@github.com/owner/repo.git", "", ""},
+		{"/local/path", "", ""},
+		{"https://github.com", "", ""},
+	}
+	for _, tt := range tests {
+		host, path := parseRemoteURL(tt.in)
+		if host != tt.wantHost || path != tt.wantPath {
+			t.Errorf("parseRemoteURL(%q) = (%q, %q), want (%q, %q)",
+				tt.in, host, path, tt.wantHost, tt.wantPath)
+		}
+	}
+}
+
 func TestGithubCloneURL(t *testing.T) {
 	tests := []struct {
 		owner    string
@@ -48,3 +79,119 @@ func TestGithubCloneURL(t *testing.T) {
 		}
 	}
 }
+
+func TestGHUserCached(t *testing.T) {
+	calls := 0
+	orig := ghUserLookup
+	ghUserLookup = func() (string, error) {
+		calls++
+		return "alice", nil
+	}
+	t.Cleanup(func() {
+		ghUserLookup = orig
+		resetGHUserCache()
+	})
+	resetGHUserCache()
+
+	for range 3 {
+		if login, err := ghUser(); err != nil || login != "alice" {
+			t.Fatalf("ghUser() = %q, %v; want alice", login, err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("lookups = %d, want 1", calls)
+	}
+
+	resetGHUserCache()
+	if _, err := ghUser(); err != nil || calls != 2 {
+		t.Errorf("after reset: lookups = %d, err = %v; want 2 lookups", calls, err)
+	}
+}
+
+func TestForkParentRefreshDue(t *testing.T) {
+	r := newTestRepo(t)
+	now := time.Now()
+	r.git("config", "remote.origin.gh-parent", "none")
+	if !r.forkParentRefreshDue("none", now) {
+		t.Error("never-checked cache should be due")
+	}
+
+	markForkParentChecked(r.Repo, now.Add(-3*24*time.Hour))
+	if r.forkParentRefreshDue("none", now) {
+		t.Error("cache checked 3 days ago should not be due with the default TTL")
+	}
+	r.Config.Thresholds.ForkParentTTL = Duration{24 * time.Hour}
+	if !r.forkParentRefreshDue("none", now) {
+		t.Error("cache checked 3 days ago should be due with a 1d TTL")
+	}
+
+	r.Config.Online = true
+	if r.forkParentRefreshDue("acme/repo", now) {
+		t.Error("with online set, a cached parent is left to the fork-rename check")
+	}
+
+	r.Config.Thresholds.ForkParentTTL = Duration{}
+	r.RefreshCache = true
+	if !r.forkParentRefreshDue("none", now) {
+		t.Error("--refresh-cache should force a lookup")
+	}
+	r.forkParentRefreshed = true
+	if r.forkParentRefreshDue("none", now) {
+		t.Error("a lookup already made in this run should not repeat")
+	}
+}
+
+func TestForkParentKeepsCacheWhenLookupFails(t *testing.T) {
+	r := newTestRepo(t)
+	r.git("remote", "add", "origin", "/nonexistent/repo.git")
+	r.git("config", "remote.origin.gh-parent", "acme/repo")
+	r.RefreshCache = true
+	if got := r.ForkParent(); got != "acme/repo" {
+		t.Errorf("ForkParent = %q, want cached acme/repo", got)
+	}
+}
+
+func TestGuardCloneMainBranch(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("a.txt", "a", "first", time.Now())
+	r.git("remote", "add", "origin", "https://github.com/me/repo.git")
+	r.git("remote", "add", "upstream", "https://github.com/oss/repo.git")
+
+	// A personal fork: RemoteCheck leaves tracking alone, the clone doesn't.
+	if err := guardCloneMainBranch(r.dir, r.Config); err != nil {
+		t.Fatalf("guardCloneMainBranch: %v", err)
+	}
+	for key, want := range map[string]string{
+		"branch.main.remote":     "upstream",
+		"branch.main.merge":      "refs/heads/main",
+		"branch.main.pushRemote": "DISABLED",
+	} {
+		if got := r.git("config", key); got != want {
+			t.Errorf("%s = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestCloneGitTimeout(t *testing.T) {
+	orig := commandTimeout
+	t.Cleanup(func() { commandTimeout = orig })
+	commandTimeout = 50 * time.Millisecond
+
+	// A server that accepts the connection but never answers.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	dest := filepath.Join(t.TempDir(), "repo")
+	err = cloneGit(context.Background(), "", "clone", "http://"+listener.Addr().String()+"/repo.git", dest)
+	if !errors.Is(err, errTimedOut) {
+		t.Errorf("cloneGit(clone) error = %v, want errTimedOut", err)
+	}
+
+	// A plain failure carries git's message.
+	err = cloneGit(context.Background(), t.TempDir(), "fetch", "upstream")
+	if err == nil || errors.Is(err, errTimedOut) || !strings.Contains(err.Error(), "not a git repository") {
+		t.Errorf("cloneGit(fetch) error = %v, want git's not-a-repository message", err)
+	}
+}
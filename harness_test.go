@@ -4,6 +4,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -71,6 +72,14 @@ func (r *testRepo) git(args ...string) string {
 	return runGit(r.t, r.dir, nil, args...)
 }
 
+// cacheForkParent records parent ("owner/repo" or "none") as origin's
+// freshly checked fork parent, so ForkParent uses it without asking GitHub.
+func (r *testRepo) cacheForkParent(parent string) {
+	r.t.Helper()
+	r.git("config", "remote.origin.gh-parent", parent)
+	r.git("config", "remote.origin.gh-parent-checked", strconv.FormatInt(time.Now().Unix(), 10))
+}
+
 // commit writes a file and commits it, stamping author and committer dates
 // so age-based checks are deterministic.
 func (r *testRepo) commit(name, content, message string, date time.Time) {
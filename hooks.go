@@ -17,6 +17,8 @@ var staleHookTemplates = map[string]int64{
 
 type HooksCheck struct{}
 
+func (c *HooksCheck) Name() string { return "hooks" }
+
 func (c *HooksCheck) Check(repo *Repo) []Result {
 	hooksDir := filepath.Join(repo.Dir, ".git", "hooks")
 	entries, err := os.ReadDir(hooksDir)
@@ -73,7 +75,7 @@ func (c *HooksCheck) Fix(repo *Repo, results []Result) []Result {
 		hooksDir := filepath.Join(repo.Dir, ".git", "hooks")
 		failed := false
 		for name := range staleHookTemplates {
-			if err := os.Remove(filepath.Join(hooksDir, name)); err != nil && !os.IsNotExist(err) {
+			if err := repo.RemoveFile(filepath.Join(hooksDir, name)); err != nil {
 				failed = true
 			}
 		}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"html/template"
+	"io"
+	"os"
+	"time"
+)
+
+// htmlPage is the data behind the --html report.
+type htmlPage struct {
+	Generated string
+	Version   string
+	Summary   repoSummary
+	Repos     []jsonRepo
+}
+
+// htmlTemplate renders a self-contained page: a summary line, then a table
+// with a row per repo whose findings expand below it. html/template escapes
+// repo names, messages, and details.
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"problems": func(results []Result) int {
+		n := 0
+		for _, r := range results {
+			if r.Status == StatusWarn || r.Status == StatusFail {
+				n++
+			}
+		}
+		return n
+	},
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>git-lint report</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em; color: #222; }
+table { border-collapse: collapse; width: 100%; }
+th, td { text-align: left; padding: 0.3em 0.6em; border-bottom: 1px solid #ddd; vertical-align: top; }
+summary { cursor: pointer; }
+ul { margin: 0.3em 0; }
+code { font-size: 90%; }
+.status { font-weight: bold; }
+.ok { color: #1a7f37; }
+.fix { color: #1a7f37; }
+.warn, .warning { color: #9a6700; }
+.fail, .critical { color: #cf222e; }
+.fixable { color: #666; font-size: 90%; }
+.meta { color: #666; }
+</style>
+</head>
+<body>
+<h1>git-lint report</h1>
+<p class="meta">Generated {{.Generated}} by git-lint {{.Version}}</p>
+<p>{{.Summary.ReposChecked}} repos: {{.Summary.ReposOK}} ok, {{.Summary.ReposWarned}} warned, {{.Summary.ReposFailed}} failed</p>
+<table>
+<thead><tr><th>Repo</th><th>Status</th><th>Findings</th></tr></thead>
+<tbody>
+{{- range .Repos}}
+<tr>
+<td>{{.Name}}</td>
+<td class="status {{.Status}}">{{.Status}}</td>
+<td>
+{{- if .Results}}
+<details{{if ne .Status "ok"}} open{{end}}>
+<summary>{{problems .Results}} of {{len .Results}} results need attention</summary>
+<ul>
+{{- range .Results}}
+<li><span class="status {{.Status}}">{{.Status}}</span> <code>{{.Name}}</code> {{.Message}}
+{{- if and .Fixable (ne .Status "fix")}} <span class="fixable">[fixable]</span>{{end}}
+{{- if .Details}}
+<ul>{{range .Details}}<li>{{.}}</li>{{end}}</ul>
+{{- end}}
+</li>
+{{- end}}
+</ul>
+</details>
+{{- end}}
+</td>
+</tr>
+{{- end}}
+</tbody>
+</table>
+</body>
+</html>
+`))
+
+// writeHTML renders the report as a self-contained HTML page for --html.
+func (rep *jsonReport) writeHTML(w io.Writer, now time.Time) error {
+	return htmlTemplate.Execute(w, htmlPage{
+		Generated: now.Format("2006-01-02 15:04"),
+		Version:   version,
+		Summary:   rep.Summary,
+		Repos:     rep.Repos,
+	})
+}
+
+// writeHTMLFile writes the HTML report to path.
+func (rep *jsonReport) writeHTMLFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := rep.writeHTML(f, time.Now()); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHTMLReport(t *testing.T) {
+	rep := newJSONReport()
+	rep.add("clean", []Result{{Name: "identity/name", Status: StatusOK, Message: "Test User"}})
+	rep.add("<script>alert(1)</script>", []Result{
+		{Name: "identity/email", Status: StatusFail, Message: `got "a<b>&c"`, Fixable: true},
+		{Name: "branch/merged[x]", Status: StatusWarn, Message: "merged", Details: []string{"abc1234 <subject>"}},
+		{Name: "remote/protocol[origin]", Status: StatusFix, Message: "set to git@github.com:o/r.git"},
+	})
+
+	var buf bytes.Buffer
+	if err := rep.writeHTML(&buf, time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if strings.Contains(out, "<script>") || strings.Contains(out, "a<b>") || strings.Contains(out, "<subject>") {
+		t.Errorf("unescaped input in HTML:\n%s", out)
+	}
+	for _, want := range []string{
+		"2 repos: 1 ok, 0 warned, 1 failed",
+		`&lt;script&gt;alert(1)&lt;/script&gt;`,
+		`<td class="status critical">critical</td>`,
+		`[fixable]`,
+		`<li>abc1234 &lt;subject&gt;</li>`,
+		"2 of 3 results need attention",
+		"Generated 2024-06-01 12:00",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("HTML missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Count(out, "[fixable]") != 1 {
+		t.Errorf("want [fixable] only on the unfixed fixable result:\n%s", out)
+	}
+}
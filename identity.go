@@ -1,9 +1,17 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
 
 type IdentityCheck struct{}
 
+func (c *IdentityCheck) Name() string { return "identity" }
+
 func (c *IdentityCheck) Check(repo *Repo) []Result {
 	var results []Result
 
@@ -21,6 +29,7 @@ func (c *IdentityCheck) Check(repo *Repo) []Result {
 			Name:    "identity/name",
 			Status:  StatusFail,
 			Message: fmt.Sprintf("got %q, want %q", name, want),
+			Details: identitySource(repo, "user.name", want),
 			Fixable: true,
 		})
 	}
@@ -45,13 +54,29 @@ func (c *IdentityCheck) Check(repo *Repo) []Result {
 				Name:    "identity/email",
 				Status:  StatusFail,
 				Message: fmt.Sprintf("got %q, want %q", localEmail, workEmail),
+				Details: identitySource(repo, "user.email", workEmail),
 				Fixable: true,
 			})
 		}
 	} else {
 		// Personal repos: effective value from any config source suffices.
 		email := repo.GitConfigEffective("user.email")
-		if email == workEmail || email == personalEmail {
+		if repo.Config.Identity.StrictPersonalEmail && workEmail != "" && email == workEmail {
+			// Strict mode: the work email on a personal repo leaks it into
+			// public history. Without a personal email there is nothing
+			// to switch to.
+			msg := fmt.Sprintf("work email %q used in personal repo, want %q", email, personalEmail)
+			if personalEmail == "" {
+				msg = fmt.Sprintf("work email %q used in personal repo; set identity.personalEmail to fix", email)
+			}
+			results = append(results, Result{
+				Name:    "identity/email",
+				Status:  StatusWarn,
+				Message: msg,
+				Details: identitySource(repo, "user.email", personalEmail),
+				Fixable: personalEmail != "",
+			})
+		} else if email == workEmail || email == personalEmail {
 			results = append(results, Result{
 				Name:    "identity/email",
 				Status:  StatusOK,
@@ -62,18 +87,138 @@ func (c *IdentityCheck) Check(repo *Repo) []Result {
 				Name:    "identity/email",
 				Status:  StatusFail,
 				Message: fmt.Sprintf("got %q, want %q or %q", email, workEmail, personalEmail),
+				Details: identitySource(repo, "user.email", workEmail, personalEmail),
 				Fixable: true,
 			})
 		}
 	}
 
+	if repo.Ref != "" {
+		results = append(results, refCommitIdentity(repo)...)
+	}
+
 	return results
 }
 
+// refCommitIdentity checks the author email of every commit on --ref that
+// isn't on main yet, so CI can gate a branch on who its commits claim to be
+// from. Work repos accept only the work email; personal repos accept either.
+func refCommitIdentity(repo *Repo) []Result {
+	accepted := acceptedEmails(repo)
+	if len(accepted) == 0 {
+		return nil
+	}
+
+	args := []string{"log", "--format=%h %ae %s", repo.Ref}
+	if mainBranch := repo.MainBranch(); mainBranch != "" {
+		base := mainBranch
+		if remote := mainRemoteRef(repo, mainBranch); remote != "" {
+			base = remote
+		}
+		args = append(args, "--not", base)
+	}
+	out, err := repo.Git(args...)
+	if err != nil {
+		return []Result{{
+			Name:    "identity/commits",
+			Status:  StatusFail,
+			Message: fmt.Sprintf("cannot read commits of %s", repo.Ref),
+		}}
+	}
+
+	var details []string
+	total := 0
+	for _, line := range strings.Split(out, "\n") {
+		if line == "" {
+			continue
+		}
+		total++
+		fields := strings.SplitN(line, " ", 3)
+		if len(fields) == 3 && !accepted[fields[1]] {
+			details = append(details, line)
+		}
+	}
+	if len(details) > 0 {
+		return []Result{{
+			Name:    "identity/commits",
+			Status:  StatusFail,
+			Message: fmt.Sprintf("%d of %d commits on %s use an unexpected author email", len(details), total, repo.Ref),
+			Details: details,
+		}}
+	}
+	return []Result{{
+		Name:    "identity/commits",
+		Status:  StatusOK,
+		Message: fmt.Sprintf("%d commits on %s use the expected email", total, repo.Ref),
+	}}
+}
+
+// identitySource explains where the effective value of key comes from and
+// flags conditional includes that would have set a wanted value but did not
+// apply to this repo, e.g. an includeIf "gitdir:~/work/" for a repo cloned
+// elsewhere.
+func identitySource(repo *Repo, key string, wants ...string) []string {
+	var details []string
+	origin := ""
+	if out, err := repo.Git("config", "--show-origin", "--get", key); err == nil {
+		origin, _, _ = strings.Cut(out, "\t")
+		details = append(details, fmt.Sprintf("%s set in %s", key, strings.TrimPrefix(origin, "file:")))
+	} else {
+		details = append(details, fmt.Sprintf("%s is not set in any config file", key))
+	}
+
+	out, _ := repo.Git("config", "--show-origin", "--get-regexp", `^includeif\..*\.path$`)
+	for _, line := range strings.Split(out, "\n") {
+		// Format: file:<including file>\tincludeif.<condition>.path <path>
+		from, entry, ok := strings.Cut(line, "\t")
+		if !ok {
+			continue
+		}
+		name, path, ok := strings.Cut(entry, " ")
+		if !ok {
+			continue
+		}
+		condition := strings.TrimSuffix(strings.TrimPrefix(name, "includeif."), ".path")
+		path = includePath(strings.TrimPrefix(from, "file:"), path)
+		value, err := repo.Git("config", "--file", path, "--get", key)
+		if err != nil || !slices.Contains(wants, value) || "file:"+path == origin {
+			continue
+		}
+		details = append(details, fmt.Sprintf("includeIf %q sets %s to %q but did not match this repo", condition, key, value))
+	}
+	return details
+}
+
+// includePath resolves an include.path value the way git does: "~/" is the
+// home directory and relative paths are relative to the including file.
+func includePath(from, path string) string {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		home, _ := os.UserHomeDir()
+		return filepath.Join(home, rest)
+	}
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(filepath.Dir(from), path)
+}
+
+// acceptedEmails returns the configured emails commits in this repo may
+// use: the work email in work repos, either email in personal repos.
+func acceptedEmails(repo *Repo) map[string]bool {
+	accepted := map[string]bool{}
+	if email := repo.Config.Identity.WorkEmail; email != "" {
+		accepted[email] = true
+	}
+	if email := repo.Config.Identity.PersonalEmail; email != "" && !repo.Work {
+		accepted[email] = true
+	}
+	return accepted
+}
+
 func (c *IdentityCheck) Fix(repo *Repo, results []Result) []Result {
 	var fixed []Result
 	for _, r := range results {
-		if r.Status != StatusFail || !r.Fixable {
+		if !r.Fixable {
 			fixed = append(fixed, r)
 			continue
 		}
@@ -108,3 +253,44 @@ func (c *IdentityCheck) Fix(repo *Repo, results []Result) []Result {
 	}
 	return fixed
 }
+
+// GHAuthCheck verifies that the gh CLI is logged in as the configured GitHub
+// account. Fork detection and --clone use the gh login, so the wrong account
+// silently picks the wrong fork. The check is opt-in: it only runs when
+// identity.githubLogin is set.
+type GHAuthCheck struct{}
+
+func (c *GHAuthCheck) Name() string { return "gh-auth" }
+
+func (c *GHAuthCheck) Check(repo *Repo) []Result {
+	want := repo.Config.Identity.GitHubLogin
+	if want == "" {
+		return nil
+	}
+	got, err := ghUser()
+	if err != nil {
+		return nil
+	}
+	return []Result{ghLoginResult(got, want)}
+}
+
+// ghLoginResult compares the authenticated gh login against the configured
+// one. GitHub logins are case-insensitive.
+func ghLoginResult(got, want string) Result {
+	if strings.EqualFold(got, want) {
+		return Result{
+			Name:    "identity/gh-login",
+			Status:  StatusOK,
+			Message: got,
+		}
+	}
+	return Result{
+		Name:    "identity/gh-login",
+		Status:  StatusWarn,
+		Message: fmt.Sprintf("gh is logged in as %q, want %q; run: gh auth switch", got, want),
+	}
+}
+
+func (c *GHAuthCheck) Fix(_ *Repo, results []Result) []Result {
+	return results
+}
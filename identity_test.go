@@ -1,6 +1,12 @@
 package main
 
-import "testing"
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
 
 func TestIdentityPersonalRepoPasses(t *testing.T) {
 	r := newTestRepo(t)
@@ -63,3 +69,96 @@ func TestIdentityWorkRepoRequiresLocalWorkEmail(t *testing.T) {
 		t.Errorf("local user.email = %q, want %q", email, "jan@acme.com")
 	}
 }
+
+func TestGHLoginResult(t *testing.T) {
+	if got := ghLoginResult("Alice", "alice"); got.Status != StatusOK {
+		t.Errorf("case-insensitive match = %+v, want ok", got)
+	}
+	if got := ghLoginResult("bob", "alice"); got.Status != StatusWarn {
+		t.Errorf("different login = %+v, want warn", got)
+	}
+}
+
+func TestIdentityStrictPersonalEmail(t *testing.T) {
+	r := newTestRepo(t)
+	r.Config.Identity.WorkEmail = "jan@acme.com"
+	r.git("config", "user.email", "jan@acme.com")
+
+	// By default the work email is accepted on personal repos.
+	got, _ := resultByName((&IdentityCheck{}).Check(r.Repo), "identity/email")
+	if got.Status != StatusOK {
+		t.Fatalf("lenient mode = %+v, want ok", got)
+	}
+
+	r.Config.Identity.StrictPersonalEmail = true
+	results := (&IdentityCheck{}).Check(r.Repo)
+	got, _ = resultByName(results, "identity/email")
+	if got.Status != StatusWarn || !got.Fixable {
+		t.Fatalf("strict mode = %+v, want fixable warn", got)
+	}
+
+	(&IdentityCheck{}).Fix(r.Repo, results)
+	if email := r.git("config", "--local", "user.email"); email != "test@example.com" {
+		t.Errorf("local user.email = %q, want personal email", email)
+	}
+
+	// Without a personal email, --fix has nothing to set.
+	r.git("config", "user.email", "jan@acme.com")
+	r.Config.Identity.PersonalEmail = ""
+	results = (&IdentityCheck{}).Check(r.Repo)
+	if got, _ = resultByName(results, "identity/email"); got.Status != StatusWarn || got.Fixable {
+		t.Fatalf("strict mode without personal email = %+v, want non-fixable warn", got)
+	}
+	(&IdentityCheck{}).Fix(r.Repo, results)
+	if email := r.git("config", "--local", "user.email"); email != "jan@acme.com" {
+		t.Errorf("local user.email = %q, want it left alone", email)
+	}
+}
+
+func TestIdentityCommitsOnRef(t *testing.T) {
+	r := newTestRepo(t)
+	now := time.Now()
+	r.commit("a.txt", "a", "base", now)
+	r.git("checkout", "-b", "feature")
+	r.commit("b.txt", "b", "mine", now)
+	r.commitAs("c.txt", "c", "theirs", "Someone Else", "else@example.com", now)
+	r.git("checkout", "main")
+
+	r.Repo.Ref = "feature"
+	got, ok := resultByName((&IdentityCheck{}).Check(r.Repo), "identity/commits")
+	if !ok || got.Status != StatusFail || len(got.Details) != 1 {
+		t.Fatalf("identity/commits = %+v, want fail with one commit", got)
+	}
+
+	r.Repo.Ref = ""
+	if _, ok := resultByName((&IdentityCheck{}).Check(r.Repo), "identity/commits"); ok {
+		t.Error("identity/commits reported without --ref")
+	}
+}
+
+func TestIdentityExplainsUnmatchedIncludeIf(t *testing.T) {
+	r := newTestRepo(t)
+	r.Config.Identity.WorkEmail = "work@acme.com"
+	r.git("config", "--unset", "user.email")
+
+	// The global config (isolated by the harness) conditionally includes a
+	// file with the right email, but only for a directory this repo is not in.
+	global := os.Getenv("GIT_CONFIG_GLOBAL")
+	include := filepath.Join(filepath.Dir(global), "personal.inc")
+	if err := os.WriteFile(include, []byte("[user]\n\temail = test@example.com\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, r.dir, nil, "config", "--global", "includeIf.gitdir:/nowhere/.path", "personal.inc")
+
+	got, _ := resultByName((&IdentityCheck{}).Check(r.Repo), "identity/email")
+	if got.Status != StatusFail {
+		t.Fatalf("identity/email = %+v, want fail", got)
+	}
+	want := []string{
+		"user.email is not set in any config file",
+		`includeIf "gitdir:/nowhere/" sets user.email to "test@example.com" but did not match this repo`,
+	}
+	if !slices.Equal(got.Details, want) {
+		t.Errorf("details = %q, want %q", got.Details, want)
+	}
+}
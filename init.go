@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// initConfig asks for the basic settings on out, reads the answers from in,
+// and writes the resulting config to path (configPath() when empty). Answers
+// default to the global git identity and defaultConfig() thresholds, so
+// pressing enter throughout yields a usable config. An existing config is
+// only replaced after confirmation.
+func initConfig(path string, in io.Reader, out io.Writer) error {
+	if path == "" {
+		path = configPath()
+	}
+	p := &prompter{in: bufio.NewReader(in), out: out}
+
+	if _, err := os.Stat(path); err == nil {
+		answer := p.ask(fmt.Sprintf("%s exists; overwrite? [y/N]", path), "")
+		if !strings.EqualFold(answer, "y") && !strings.EqualFold(answer, "yes") {
+			fmt.Fprintf(out, "kept %s\n", path)
+			return nil
+		}
+	}
+
+	cfg := defaultConfig()
+	for _, org := range strings.Split(p.ask("Work GitHub orgs (comma-separated)", ""), ",") {
+		if org = strings.TrimSpace(org); org != "" {
+			cfg.WorkOrgs = append(cfg.WorkOrgs, org)
+		}
+	}
+	cfg.Identity.Name = p.ask("Name", globalGitConfig("user.name"))
+	cfg.Identity.WorkEmail = p.ask("Work email", "")
+	cfg.Identity.PersonalEmail = p.ask("Personal email", globalGitConfig("user.email"))
+
+	t := &cfg.Thresholds
+	t.StashMaxAge.Duration = p.askDuration("Max stash age", t.StashMaxAge.Duration)
+	t.StashMaxCount = p.askInt("Max stash entries", t.StashMaxCount)
+	t.UncommittedMaxAge.Duration = p.askDuration("Max age of uncommitted changes", t.UncommittedMaxAge.Duration)
+	t.UnpushedMaxAge.Duration = p.askDuration("Max age of unpushed commits (0s disables)", t.UnpushedMaxAge.Duration)
+
+	if err := writeConfig(path, cfg); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "wrote %s\n", path)
+	for _, w := range cfg.warnings() {
+		fmt.Fprintf(out, "warning: %s\n", w)
+	}
+	return nil
+}
+
+// prompter reads line-based answers to prompts. At end of input every
+// question takes its default.
+type prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// ask prints prompt with def in brackets and returns the trimmed answer, or
+// def if the answer is empty.
+func (p *prompter) ask(prompt, def string) string {
+	if def != "" {
+		fmt.Fprintf(p.out, "%s [%s]: ", prompt, def)
+	} else {
+		fmt.Fprintf(p.out, "%s: ", prompt)
+	}
+	line, _ := p.in.ReadString('\n')
+	if answer := strings.TrimSpace(line); answer != "" {
+		return answer
+	}
+	return def
+}
+
+// askDuration asks until the answer parses as a duration like "7d" or "12h".
+func (p *prompter) askDuration(prompt string, def time.Duration) time.Duration {
+	for {
+		d, err := parseDuration(p.ask(prompt, formatDurationConfig(def)))
+		if err == nil {
+			return d
+		}
+		fmt.Fprintf(p.out, "  %v\n", err)
+	}
+}
+
+// askInt asks until the answer is a non-negative integer.
+func (p *prompter) askInt(prompt string, def int) int {
+	for {
+		answer := p.ask(prompt, strconv.Itoa(def))
+		n, err := strconv.Atoi(answer)
+		if err == nil && n >= 0 {
+			return n
+		}
+		fmt.Fprintf(p.out, "  invalid number %q\n", answer)
+	}
+}
+
+// globalGitConfig returns the global git config value for key, or "" if it
+// is unset.
+func globalGitConfig(key string) string {
+	out, err := exec.Command("git", "config", "--global", key).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimRight(string(out), "\n")
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInitConfig(t *testing.T) {
+	dir := t.TempDir()
+	global := filepath.Join(dir, "gitconfig")
+	if err := os.WriteFile(global, []byte("[user]\n\tname = Alice Example\n\temail = alice@example.com\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GIT_CONFIG_GLOBAL", global)
+	path := filepath.Join(dir, "git-lint", "config.json")
+
+	// Orgs, name, work email, personal email, then thresholds; an invalid
+	// duration is asked again.
+	answers := "acme, acme-labs\n\nalice@acme.com\n\nsoon\n3d\n5\n\n\n"
+	if err := initConfig(path, strings.NewReader(answers), io.Discard); err != nil {
+		t.Fatalf("initConfig: %v", err)
+	}
+	cfg, err := loadConfig(path)
+	if err != nil {
+		t.Fatalf("loading written config: %v", err)
+	}
+	if strings.Join(cfg.WorkOrgs, ",") != "acme,acme-labs" {
+		t.Errorf("WorkOrgs = %v", cfg.WorkOrgs)
+	}
+	if cfg.Identity.Name != "Alice Example" || cfg.Identity.PersonalEmail != "alice@example.com" {
+		t.Errorf("identity = %+v, want defaults from global git config", cfg.Identity)
+	}
+	if cfg.Identity.WorkEmail != "alice@acme.com" {
+		t.Errorf("WorkEmail = %q", cfg.Identity.WorkEmail)
+	}
+	if cfg.Thresholds.StashMaxAge.Duration != 3*24*time.Hour || cfg.Thresholds.StashMaxCount != 5 {
+		t.Errorf("stash thresholds = %+v", cfg.Thresholds)
+	}
+	if cfg.Thresholds.UncommittedMaxAge.Duration != 24*time.Hour {
+		t.Errorf("UncommittedMaxAge = %v, want default 1d", cfg.Thresholds.UncommittedMaxAge)
+	}
+
+	// An existing config survives unless the overwrite is confirmed.
+	if err := initConfig(path, strings.NewReader("n\n"), io.Discard); err != nil {
+		t.Fatalf("initConfig on existing config: %v", err)
+	}
+	if cfg, _ := loadConfig(path); len(cfg.WorkOrgs) != 2 {
+		t.Error("declining the overwrite replaced the config")
+	}
+	if err := initConfig(path, strings.NewReader("y\n"), io.Discard); err != nil {
+		t.Fatalf("initConfig overwrite: %v", err)
+	}
+	if cfg, _ := loadConfig(path); len(cfg.WorkOrgs) != 0 {
+		t.Errorf("after confirmed overwrite with defaults, WorkOrgs = %v", cfg.WorkOrgs)
+	}
+}
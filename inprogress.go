@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// InProgressCheck reports a merge, rebase, cherry-pick, revert, am, or
+// bisect that was started and never finished, so repos abandoned mid-way
+// stand out from ones with ordinary uncommitted changes. Finishing or
+// aborting the operation is up to the user, so it only warns. A MERGE_HEAD
+// that MergeHeadCheck considers stale is left to that check.
+type InProgressCheck struct{}
+
+func (c *InProgressCheck) Name() string { return "in-progress" }
+
+func (c *InProgressCheck) Check(repo *Repo) []Result {
+	if repo.Ref != "" {
+		return refNotApplicable("state/in-progress")
+	}
+	ops, details := inProgressOperations(repo)
+	if len(ops) == 0 {
+		return nil
+	}
+	if unmerged, err := repo.Git("diff", "--name-only", "--diff-filter=U"); err == nil && unmerged != "" {
+		files := strings.Split(unmerged, "\n")
+		details = append(details, fmt.Sprintf("%d files with conflicts: %s", len(files), strings.Join(files, ", ")))
+	}
+	return []Result{{
+		Name:    "state/in-progress",
+		Status:  StatusWarn,
+		Message: fmt.Sprintf("%s in progress; finish or abort it", strings.Join(ops, " and ")),
+		Details: details,
+	}}
+}
+
+func (c *InProgressCheck) Fix(_ *Repo, results []Result) []Result {
+	return results
+}
+
+// inProgressOperations returns the operations the git directory's state
+// files show as in progress, with detail lines where git records more.
+func inProgressOperations(repo *Repo) (ops, details []string) {
+	exists := func(name string) bool {
+		_, err := os.Stat(gitPath(repo, name))
+		return err == nil
+	}
+
+	if data, err := os.ReadFile(gitPath(repo, "MERGE_HEAD")); err == nil {
+		if !mergeHeadStale(repo, strings.Fields(string(data))) {
+			ops = append(ops, "merge")
+		}
+	}
+	for _, dir := range []string{"rebase-merge", "rebase-apply"} {
+		if !exists(dir) {
+			continue
+		}
+		if dir == "rebase-apply" && exists(filepath.Join(dir, "applying")) {
+			ops = append(ops, "am")
+			break
+		}
+		ops = append(ops, "rebase")
+		if head, err := os.ReadFile(gitPath(repo, filepath.Join(dir, "head-name"))); err == nil {
+			details = append(details, "rebasing "+strings.TrimPrefix(strings.TrimSpace(string(head)), "refs/heads/"))
+		}
+		break
+	}
+	if exists("CHERRY_PICK_HEAD") {
+		ops = append(ops, "cherry-pick")
+	}
+	if exists("REVERT_HEAD") {
+		ops = append(ops, "revert")
+	}
+	if exists("BISECT_LOG") {
+		ops = append(ops, "bisect")
+	}
+	return ops, details
+}
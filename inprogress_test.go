@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInProgressCheckClean(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("a.txt", "a", "first", time.Now())
+	if results := (&InProgressCheck{}).Check(r.Repo); results != nil {
+		t.Errorf("clean repo: got %+v, want none", results)
+	}
+}
+
+func TestInProgressCheckConflictedMerge(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("a.txt", "a", "first", time.Now())
+	r.git("checkout", "-b", "feature")
+	r.commit("a.txt", "feature", "feature edit", time.Now())
+	r.git("checkout", "main")
+	r.commit("a.txt", "main", "main edit", time.Now())
+	if out, err := gitInDir(context.Background(), r.dir, "merge", "feature"); err == nil {
+		t.Fatalf("merge unexpectedly succeeded: %s", out)
+	}
+
+	got, ok := resultByName((&InProgressCheck{}).Check(r.Repo), "state/in-progress")
+	if !ok || got.Status != StatusWarn || got.Fixable {
+		t.Fatalf("state/in-progress = %+v, want non-fixable warn", got)
+	}
+	if !strings.HasPrefix(got.Message, "merge in progress") {
+		t.Errorf("message = %q, want it to name the merge", got.Message)
+	}
+	if len(got.Details) != 1 || !strings.Contains(got.Details[0], "a.txt") {
+		t.Errorf("details = %q, want the conflicted file", got.Details)
+	}
+}
+
+func TestInProgressCheckRebase(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("a.txt", "a", "first", time.Now())
+	dir := filepath.Join(r.dir, ".git", "rebase-merge")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "head-name"), []byte("refs/heads/topic\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, _ := resultByName((&InProgressCheck{}).Check(r.Repo), "state/in-progress")
+	if got.Message != "rebase in progress; finish or abort it" || len(got.Details) != 1 || got.Details[0] != "rebasing topic" {
+		t.Errorf("state/in-progress = %+v, want rebase of topic", got)
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+)
+
+// junitTestSuites is the document written by --format junit: one suite per
+// repo and one test case per result that isn't ok, named after the rule, so
+// findings show up next to unit test results in CI dashboards.
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// writeJUnit renders the report as JUnit XML. Failures become <failure>,
+// warnings become <skipped> with the details in <system-out>, and fixed
+// results pass with a note of what was fixed. encoding/xml escapes messages.
+func (rep *jsonReport) writeJUnit(w io.Writer) error {
+	doc := junitTestSuites{}
+	for _, repo := range rep.Repos {
+		suite := junitTestSuite{Name: repo.Name}
+		for _, r := range repo.Results {
+			if r.Status == StatusOK {
+				continue
+			}
+			tc := junitTestCase{Name: r.Name, ClassName: repo.Name}
+			details := strings.Join(r.Details, "\n")
+			switch r.Status {
+			case StatusFail:
+				tc.Failure = &junitMessage{Message: r.Message, Text: details}
+				suite.Failures++
+			case StatusWarn:
+				tc.Skipped = &junitMessage{Message: r.Message}
+				tc.SystemOut = strings.TrimSpace(r.Message + "\n" + details)
+				suite.Skipped++
+			default:
+				tc.SystemOut = r.Message
+			}
+			suite.Cases = append(suite.Cases, tc)
+			suite.Tests++
+		}
+		doc.Tests += suite.Tests
+		doc.Failures += suite.Failures
+		doc.Skipped += suite.Skipped
+		doc.Suites = append(doc.Suites, suite)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
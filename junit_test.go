@@ -0,0 +1,44 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestJUnitReport(t *testing.T) {
+	rep := newJSONReport()
+	rep.add("clean", []Result{{Name: "identity/name", Status: StatusOK}})
+	rep.add("messy", []Result{
+		{Name: "identity/email", Status: StatusFail, Message: `got "a<b>&c", want "x"`},
+		{Name: "branch/merged[x]", Status: StatusWarn, Message: "merged", Details: []string{"abc1234 subject"}},
+		{Name: "identity/name", Status: StatusOK},
+	})
+
+	var buf bytes.Buffer
+	if err := rep.writeJUnit(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(buf.String(), "<?xml") {
+		t.Errorf("missing XML header: %q", buf.String())
+	}
+
+	var got junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid XML %q: %v", buf.String(), err)
+	}
+	if got.Tests != 2 || got.Failures != 1 || got.Skipped != 1 || len(got.Suites) != 2 {
+		t.Fatalf("testsuites = %+v, want 2 tests, 1 failure, 1 skipped across 2 suites", got)
+	}
+	messy := got.Suites[1]
+	if messy.Name != "messy" || len(messy.Cases) != 2 {
+		t.Fatalf("suite = %+v, want messy with 2 cases", messy)
+	}
+	if c := messy.Cases[0]; c.Name != "identity/email" || c.Failure == nil || c.Failure.Message != `got "a<b>&c", want "x"` {
+		t.Errorf("fail case = %+v, want failure with the original message", c)
+	}
+	if c := messy.Cases[1]; c.Skipped == nil || !strings.Contains(c.SystemOut, "abc1234 subject") {
+		t.Errorf("warn case = %+v, want skipped with details in system-out", c)
+	}
+}
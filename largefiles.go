@@ -0,0 +1,171 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// defaultLargeFileMaxBytes is the size limit when largeFileMaxBytes is unset.
+const defaultLargeFileMaxBytes = 5 << 20
+
+// LargeFileCheck warns about tracked files above thresholds.largeFileMaxBytes
+// (default 5MB), which are usually build artifacts committed by accident.
+// Files stored with Git LFS (filter=lfs) are skipped. Reading every file's
+// size costs time on big repos, so the check is off unless the checks config
+// enables "large-files".
+type LargeFileCheck struct{}
+
+func (c *LargeFileCheck) Name() string { return "large-files" }
+
+func (c *LargeFileCheck) Check(repo *Repo) []Result {
+	limit := repo.Config.Thresholds.LargeFileMaxBytes
+	if limit <= 0 {
+		limit = defaultLargeFileMaxBytes
+	}
+	sizes, err := trackedFileSizes(repo)
+	if err != nil {
+		return nil
+	}
+	what, status := "tracked", StatusWarn
+	if repo.Staged {
+		// A staged file is not committed yet, so block the commit.
+		what, status = "staged", StatusFail
+	}
+
+	var large []string
+	for path, size := range sizes {
+		if size > limit {
+			large = append(large, path)
+		}
+	}
+	slices.Sort(large)
+	large = withoutLFS(repo, large)
+
+	if len(large) > 0 {
+		var details []string
+		for _, path := range large {
+			details = append(details, fmt.Sprintf("%s (%s)", path, formatBytes(sizes[path])))
+		}
+		return []Result{{
+			Name:    "files/large",
+			Status:  status,
+			Message: fmt.Sprintf("%d %s files larger than %s", len(large), what, formatBytes(limit)),
+			Details: details,
+		}}
+	}
+	return []Result{{
+		Name:    "files/large",
+		Status:  StatusOK,
+		Message: fmt.Sprintf("no %s files larger than %s", what, formatBytes(limit)),
+	}}
+}
+
+func (c *LargeFileCheck) Fix(_ *Repo, results []Result) []Result {
+	return results
+}
+
+// trackedFileSizes maps each tracked path to its size: the blob size in the
+// --ref tree, the staged blob size of the files added or changed in the
+// index under --staged, or else the size of the working tree file, which
+// also covers files that are staged but not committed yet.
+func trackedFileSizes(repo *Repo) (map[string]int64, error) {
+	sizes := make(map[string]int64)
+	if repo.Staged {
+		out, err := repo.Git("diff", "--cached", "--name-only", "-z", "--diff-filter=ACMR")
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range strings.Split(out, "\x00") {
+			if path == "" {
+				continue
+			}
+			size, err := repo.Git("cat-file", "-s", ":"+path)
+			if err != nil {
+				continue
+			}
+			if n, err := strconv.ParseInt(size, 10, 64); err == nil {
+				sizes[path] = n
+			}
+		}
+		return sizes, nil
+	}
+	if repo.Ref != "" {
+		out, err := repo.Git("ls-tree", "-r", "-l", repo.Ref)
+		if err != nil {
+			return nil, err
+		}
+		for _, line := range strings.Split(out, "\n") {
+			// Format: <mode> <type> <object> <size>\t<path>
+			meta, path, ok := strings.Cut(line, "\t")
+			fields := strings.Fields(meta)
+			if !ok || len(fields) < 4 || fields[1] != "blob" {
+				continue
+			}
+			if size, err := strconv.ParseInt(fields[3], 10, 64); err == nil {
+				sizes[path] = size
+			}
+		}
+		return sizes, nil
+	}
+
+	out, err := repo.Git("ls-files")
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range strings.Split(out, "\n") {
+		if path == "" {
+			continue
+		}
+		info, err := os.Lstat(filepath.Join(repo.Dir, filepath.FromSlash(path)))
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		sizes[path] = info.Size()
+	}
+	return sizes, nil
+}
+
+// withoutLFS returns paths, in order, minus those whose filter attribute is
+// lfs.
+func withoutLFS(repo *Repo, paths []string) []string {
+	if len(paths) == 0 {
+		return nil
+	}
+	args := append([]string{"check-attr", "filter", "--"}, paths...)
+	out, err := repo.Git(args...)
+	if err != nil {
+		return paths
+	}
+	lfs := make(map[string]bool)
+	for _, line := range strings.Split(out, "\n") {
+		// Format: <path>: filter: <value>
+		if path, ok := strings.CutSuffix(line, ": filter: lfs"); ok {
+			lfs[path] = true
+		}
+	}
+	var kept []string
+	for _, path := range paths {
+		if !lfs[path] {
+			kept = append(kept, path)
+		}
+	}
+	return kept
+}
+
+// formatBytes formats a size in bytes with a binary unit, e.g. "5.0MB".
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLargeFileCheck(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("small.txt", "small", "small file", time.Now())
+	r.commit("build.tar", strings.Repeat("x", 2048), "artifact", time.Now())
+	r.commit("video.mp4", strings.Repeat("v", 4096), "lfs file", time.Now())
+	r.commit(".gitattributes", "*.mp4 filter=lfs diff=lfs merge=lfs -text\n", "track mp4 with lfs", time.Now())
+	r.Config.Thresholds.LargeFileMaxBytes = 1024
+
+	got, _ := resultByName((&LargeFileCheck{}).Check(r.Repo), "files/large")
+	if got.Status != StatusWarn || len(got.Details) != 1 || got.Details[0] != "build.tar (2.0KB)" {
+		t.Errorf("files/large = %+v, want warn listing only build.tar", got)
+	}
+
+	r.Config.Thresholds.LargeFileMaxBytes = 0 // default 5MB
+	if got, _ := resultByName((&LargeFileCheck{}).Check(r.Repo), "files/large"); got.Status != StatusOK {
+		t.Errorf("default limit = %+v, want ok", got)
+	}
+}
+
+func TestLargeFileCheckStaged(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("build.tar", strings.Repeat("x", 2048), "artifact", time.Now())
+	r.Config.Thresholds.LargeFileMaxBytes = 1024
+	r.Repo.Staged = true
+
+	// Committed files are not in the next commit.
+	if got, _ := resultByName((&LargeFileCheck{}).Check(r.Repo), "files/large"); got.Status != StatusOK {
+		t.Errorf("nothing staged = %+v, want ok", got)
+	}
+
+	if err := os.WriteFile(filepath.Join(r.dir, "dump.sql"), []byte(strings.Repeat("d", 4096)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	r.git("add", "dump.sql")
+	got, _ := resultByName((&LargeFileCheck{}).Check(r.Repo), "files/large")
+	if got.Status != StatusFail || len(got.Details) != 1 || got.Details[0] != "dump.sql (4.0KB)" {
+		t.Errorf("staged dump.sql = %+v, want fail listing only dump.sql", got)
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := map[int64]string{
+		512:             "512B",
+		2048:            "2.0KB",
+		5 << 20:         "5.0MB",
+		3<<30 + 512<<20: "3.5GB",
+	}
+	for n, want := range tests {
+		if got := formatBytes(n); got != want {
+			t.Errorf("formatBytes(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// MailmapCheck warns when a repo has a .mailmap that doesn't mention the
+// current user.email. Shortlogs and blame then show the raw commit identity
+// instead of the canonical one. Editing .mailmap is a project decision, so
+// there is no fix.
+type MailmapCheck struct{}
+
+var mailmapEmail = regexp.MustCompile(`<([^>]*)>`)
+
+func (c *MailmapCheck) Name() string { return "mailmap" }
+
+func (c *MailmapCheck) Check(repo *Repo) []Result {
+	data, err := os.ReadFile(filepath.Join(repo.Dir, ".mailmap"))
+	if err != nil {
+		return nil
+	}
+	email := repo.GitConfigEffective("user.email")
+	if email == "" {
+		return nil
+	}
+
+	if !mailmapHasEmail(string(data), email) {
+		return []Result{{
+			Name:    "identity/mailmap",
+			Status:  StatusWarn,
+			Message: fmt.Sprintf(".mailmap has no entry for %s", email),
+		}}
+	}
+
+	name := repo.GitConfigEffective("user.name")
+	mapped, _ := repo.Git("check-mailmap", fmt.Sprintf("%s <%s>", name, email))
+	return []Result{{
+		Name:    "identity/mailmap",
+		Status:  StatusOK,
+		Message: fmt.Sprintf("%s maps to %s", email, mapped),
+	}}
+}
+
+func (c *MailmapCheck) Fix(_ *Repo, results []Result) []Result {
+	return results
+}
+
+// mailmapHasEmail reports whether email appears as either the proper or the
+// commit email on any .mailmap line. Emails compare case-insensitively, as
+// git does.
+func mailmapHasEmail(mailmap, email string) bool {
+	for _, line := range strings.Split(mailmap, "\n") {
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		for _, m := range mailmapEmail.FindAllStringSubmatch(line, -1) {
+			if strings.EqualFold(m[1], email) {
+				return true
+			}
+		}
+	}
+	return false
+}
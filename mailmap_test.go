@@ -0,0 +1,47 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMailmapHasEmail(t *testing.T) {
+	mailmap := "# Contributors\nJane Doe <jane@example.com> <jdoe@old.example>\n# Bob <bob@example.com>\n"
+	tests := []struct {
+		email string
+		want  bool
+	}{
+		{"jane@example.com", true},
+		{"JDoe@old.example", true},
+		{"bob@example.com", false},
+		{"other@example.com", false},
+	}
+	for _, tt := range tests {
+		if got := mailmapHasEmail(mailmap, tt.email); got != tt.want {
+			t.Errorf("mailmapHasEmail(%q) = %v, want %v", tt.email, got, tt.want)
+		}
+	}
+}
+
+func TestMailmapCheck(t *testing.T) {
+	r := newTestRepo(t)
+	if results := (&MailmapCheck{}).Check(r.Repo); len(results) != 0 {
+		t.Errorf("no .mailmap: got %+v, want none", results)
+	}
+
+	path := filepath.Join(r.dir, ".mailmap")
+	if err := os.WriteFile(path, []byte("Someone <someone@example.com>\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := resultByName((&MailmapCheck{}).Check(r.Repo), "identity/mailmap"); got.Status != StatusWarn {
+		t.Errorf("missing entry = %+v, want warn", got)
+	}
+
+	if err := os.WriteFile(path, []byte("Test User <test@example.com>\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := resultByName((&MailmapCheck{}).Check(r.Repo), "identity/mailmap"); got.Status != StatusOK {
+		t.Errorf("present entry = %+v, want ok", got)
+	}
+}
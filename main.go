@@ -1,13 +1,16 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"flag"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
 )
 
 // ANSI escape codes for TTY output.
@@ -21,26 +24,74 @@ const (
 	ansiCyan   = "\033[36m"
 )
 
+// Exit codes, so wrappers can tell findings from misconfiguration and from
+// an empty scan.
+const (
+	exitOK       = 0 // all checks pass (warnings are acceptable)
+	exitFindings = 1 // at least one check failed (or warned, with warningsAsErrors)
+	exitError    = 2 // config, usage, or runtime error
+	exitNoRepos  = 3 // nothing to scan: no git repos found, or not a repo
+)
+
 // version is set at build time via -ldflags "-X main.version=..."
 var version = "dev"
 
-var isTTY bool
+// stringList is a flag.Value that collects every use of a repeatable flag.
+type stringList []string
+
+func (l *stringList) String() string { return strings.Join(*l, ",") }
 
-func init() {
-	if stat, err := os.Stdout.Stat(); err == nil {
-		isTTY = (stat.Mode() & os.ModeCharDevice) != 0
+func (l *stringList) Set(v string) error {
+	*l = append(*l, v)
+	return nil
+}
+
+// useColor reports whether text output should use ANSI colors: only when
+// stdout is a terminal, and neither --no-color nor NO_COLOR
+// (https://no-color.org) asks for plain output.
+func useColor(noColor bool) bool {
+	if noColor || os.Getenv("NO_COLOR") != "" {
+		return false
 	}
+	stat, err := os.Stdout.Stat()
+	return err == nil && (stat.Mode()&os.ModeCharDevice) != 0
 }
 
 func main() {
 	dir := flag.String("C", "", "run as if started in this directory")
 	clone := flag.String("clone", "", "clone a GitHub repo and configure it")
+	classify := flag.Bool("classify", false, "print whether the repo is work or personal")
 	fix := flag.Bool("fix", false, "auto-fix fixable violations")
+	fixDryRun := flag.Bool("fix-dry-run", false, "show what --fix would change without changing anything")
+	refreshCache := flag.Bool("refresh-cache", false, "look up cached GitHub fork parents again")
+	timeout := flag.Duration("timeout", defaultCommandTimeout, "kill each network command (gh, git ls-remote, clone, fetch) after this long (0 = never)")
 	var recursive bool
 	flag.BoolVar(&recursive, "R", false, "check each git repo in subdirectories")
 	flag.BoolVar(&recursive, "recursive", false, "check each git repo in subdirectories")
+	var only, skip stringList
+	flag.Var(&only, "only", "run only this check (repeatable)")
+	flag.Var(&skip, "skip", "don't run this check (repeatable)")
+	var excludes stringList
+	flag.Var(&excludes, "exclude", "with -R, skip directories matching this glob (repeatable)")
+	depth := flag.Int("depth", 1, "with -R, how many directory levels to search for repos")
 	verbose := flag.Bool("verbose", false, "show all checks and all detail lines")
 	quiet := flag.Bool("quiet", false, "suppress detail lines")
+	changedOnly := flag.Bool("changed-only", false, "with -R, list only repos that were fixed or still have problems")
+	maxDetails := flag.Int("max-details", 0, "detail lines per result (-1 = unlimited, 0 = none); overrides --verbose/--quiet")
+	format := flag.String("format", "text", "output format (text, json, junit, or tsv)")
+	groupBy := flag.String("group-by", "", "group output under headers (category)")
+	stat := flag.Bool("stat", false, "print only aggregate counts")
+	metrics := flag.Bool("metrics", false, "print aggregate counts as Prometheus metrics")
+	warningsAsErrors := flag.Bool("warnings-as-errors", false, "exit 1 when any check warns, not only when one fails")
+	trustLocalConfig := flag.Bool("trust-local-config", false, "apply each repo's .git-lint.json over the config")
+	htmlFile := flag.String("html", "", "also write the results as an HTML report to this file")
+	noColor := flag.Bool("no-color", false, "disable colored output (also set by NO_COLOR)")
+	jsonResults := flag.Bool("json", false, "print results as JSON (an array, or an object keyed by repo with -R)")
+	ref := flag.String("ref", "", "check this ref's commits and tree instead of the working tree")
+	staged := flag.Bool("staged", false, "check only identity, signing, and staged files, e.g. from a pre-commit hook")
+	configDir := flag.String("config-dir", "", "keep config and state files in this directory (also set by "+configDirEnv+")")
+	initCfg := flag.Bool("init", false, "create a config file by answering a few questions")
+	configFile := flag.String("config", "", "read config from this file instead of the default path")
 	showVersion := flag.Bool("version", false, "print version and exit")
 
 	// Probe mode flags
@@ -64,11 +115,32 @@ func main() {
 		fmt.Println("git-lint version " + version)
 		return
 	}
+	commandTimeout = *timeout
 
-	cfg, err := loadConfig()
+	if *configDir != "" {
+		// Resolve now, before -C changes the working directory.
+		dir, err := filepath.Abs(*configDir)
+		if err == nil {
+			err = os.Setenv(configDirEnv, dir)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(exitError)
+		}
+	}
+
+	if *initCfg {
+		if err := initConfig(*configFile, os.Stdin, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(exitError)
+		}
+		return
+	}
+
+	cfg, err := loadConfig(*configFile)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "error: %v\n", err)
-		os.Exit(2)
+		os.Exit(exitError)
 	}
 
 	applyFlags(cfg,
@@ -76,10 +148,31 @@ func main() {
 		*identityName, *workEmail, *personalEmail,
 		*stashMaxAge, *stashMaxCount, *uncommittedMaxAge, *unpushedMaxAge,
 	)
+	cfg.ExcludeDirs = append(cfg.ExcludeDirs, excludes...)
+	if *warningsAsErrors {
+		cfg.WarningsAsErrors = true
+	}
+	if *trustLocalConfig {
+		cfg.TrustLocalConfig = true
+	}
+	for _, w := range cfg.warnings() {
+		fmt.Fprintf(os.Stderr, "warning: %s\n", w)
+	}
+
+	if !*describe {
+		if err := preflight(*clone != ""); err != nil {
+			if *path != "" {
+				outputProbeResult(probeResult{Status: "critical", Message: err.Error()})
+				return
+			}
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(exitError)
+		}
+	}
 
 	if err := checkGlobalEmail(cfg); err != nil {
 		fmt.Fprintf(os.Stderr, "error: %v\n", err)
-		os.Exit(2)
+		os.Exit(exitError)
 	}
 
 	if *describe {
@@ -92,26 +185,131 @@ func main() {
 		return
 	}
 
+	if *htmlFile != "" {
+		// Resolve before -C changes the working directory.
+		abs, err := filepath.Abs(*htmlFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(exitError)
+		}
+		*htmlFile = abs
+	}
+
 	if *dir != "" {
 		if err := os.Chdir(*dir); err != nil {
 			fmt.Fprintf(os.Stderr, "error: %v\n", err)
-			os.Exit(2)
+			os.Exit(exitError)
 		}
 	}
 
 	if *clone != "" {
 		if err := cloneRepo(cfg, *clone); err != nil {
 			fmt.Fprintf(os.Stderr, "error: %v\n", err)
-			os.Exit(2)
+			os.Exit(exitError)
 		}
 		return
 	}
 
+	var maxDetailsOverride *int
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "max-details" {
+			maxDetailsOverride = maxDetails
+		}
+	})
+	if *maxDetails < -1 {
+		fmt.Fprintf(os.Stderr, "error: invalid --max-details %d (want -1 or more)\n", *maxDetails)
+		os.Exit(exitError)
+	}
+
+	if *format != "text" && *format != "json" && *format != "junit" && *format != "tsv" {
+		fmt.Fprintf(os.Stderr, "error: invalid --format %q (want text, json, junit, or tsv)\n", *format)
+		os.Exit(exitError)
+	}
+
+	if *jsonResults {
+		if *format != "text" || *stat {
+			fmt.Fprintf(os.Stderr, "error: --json cannot be combined with --format or --stat\n")
+			os.Exit(exitError)
+		}
+		*format = "results"
+	}
+
+	if *stat {
+		if *format != "text" {
+			fmt.Fprintf(os.Stderr, "error: --stat cannot be combined with --format %s\n", *format)
+			os.Exit(exitError)
+		}
+		*format = "stat"
+	}
+
+	if *metrics {
+		if *format != "text" {
+			fmt.Fprintf(os.Stderr, "error: --metrics cannot be combined with --format, --json, or --stat\n")
+			os.Exit(exitError)
+		}
+		*format = "metrics"
+	}
+
+	if *depth < 1 {
+		fmt.Fprintf(os.Stderr, "error: invalid --depth %d (want 1 or more)\n", *depth)
+		os.Exit(exitError)
+	}
+
+	if len(only) > 0 && len(skip) > 0 {
+		fmt.Fprintf(os.Stderr, "error: --only cannot be combined with --skip\n")
+		os.Exit(exitError)
+	}
+	for _, name := range slices.Concat(only, skip) {
+		if !isCheckName(name) {
+			fmt.Fprintf(os.Stderr, "error: unknown check %q\n", name)
+			os.Exit(exitError)
+		}
+	}
+
+	if *groupBy != "" && *groupBy != "category" {
+		fmt.Fprintf(os.Stderr, "error: invalid --group-by %q (want category)\n", *groupBy)
+		os.Exit(exitError)
+	}
+
+	if *fixDryRun {
+		*fix = true
+	}
+	if *ref != "" && *fix {
+		fmt.Fprintf(os.Stderr, "error: --fix and --fix-dry-run cannot be combined with --ref\n")
+		os.Exit(exitError)
+	}
+	if *staged {
+		if recursive || *ref != "" {
+			fmt.Fprintf(os.Stderr, "error: --staged cannot be combined with -R or --ref\n")
+			os.Exit(exitError)
+		}
+		if len(only) == 0 {
+			only = stagedChecks
+		}
+	}
+
+	if *classify {
+		os.Exit(classifyRepo(cfg, *verbose))
+	}
+
 	opts := lintOptions{
-		cfg:     cfg,
-		fix:     *fix,
-		verbose: *verbose,
-		quiet:   *quiet,
+		cfg:          cfg,
+		fix:          *fix,
+		dryRun:       *fixDryRun,
+		refreshCache: *refreshCache,
+		htmlFile:     *htmlFile,
+		verbose:      *verbose,
+		quiet:        *quiet,
+		changedOnly:  *changedOnly,
+		groupBy:      *groupBy,
+		format:       *format,
+		maxDetails:   maxDetailsOverride,
+		ref:          *ref,
+		color:        useColor(*noColor),
+		depth:        *depth,
+		only:         only,
+		staged:       *staged,
+		skip:         skip,
 	}
 
 	if recursive {
@@ -121,7 +319,7 @@ func main() {
 	wd, err := os.Getwd()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "error: %v\n", err)
-		os.Exit(2)
+		os.Exit(exitError)
 	}
 	os.Exit(lintRepo(wd, opts))
 }
@@ -166,6 +364,20 @@ func applyFlags(cfg *Config,
 	}
 }
 
+// preflight verifies that git, and gh when needGH is set, are on PATH.
+// Without it a missing git surfaces later as "not a git repository".
+func preflight(needGH bool) error {
+	if _, err := exec.LookPath("git"); err != nil {
+		return fmt.Errorf("git not found on PATH")
+	}
+	if needGH {
+		if _, err := exec.LookPath("gh"); err != nil {
+			return fmt.Errorf("gh not found on PATH (needed for --clone)")
+		}
+	}
+	return nil
+}
+
 // checkGlobalEmail verifies that the global git user.email matches the
 // configured personal email. A mismatched global email causes repos to be
 // misclassified as work or personal.
@@ -185,49 +397,127 @@ func checkGlobalEmail(cfg *Config) error {
 	return nil
 }
 
+// classifyRepo prints "work" or "personal" for the repo in the current
+// directory, followed by the matching rule in verbose mode.
+func classifyRepo(cfg *Config, verbose bool) int {
+	wd, err := os.Getwd()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		return exitError
+	}
+	repo, err := NewRepo(wd, cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		if errors.Is(err, errNotARepo) {
+			return exitNoRepos
+		}
+		return exitError
+	}
+	fmt.Println(repoKind(repo, verbose))
+	return exitOK
+}
+
+// repoKind returns "work" or "personal", with the classification reason
+// appended in verbose mode.
+func repoKind(repo *Repo, verbose bool) string {
+	if !repo.Work {
+		if verbose {
+			return "personal (no remote matches a work org)"
+		}
+		return "personal"
+	}
+	if verbose && repo.WorkReason != "" {
+		return fmt.Sprintf("work (%s)", repo.WorkReason)
+	}
+	return "work"
+}
+
 type lintOptions struct {
-	cfg     *Config
-	fix     bool
-	verbose bool
-	quiet   bool
+	cfg          *Config
+	fix          bool
+	dryRun       bool   // --fix-dry-run: run fixes without changing anything
+	refreshCache bool   // --refresh-cache: query GitHub again instead of trusting cached lookups
+	htmlFile     string // --html: also write an HTML report here
+	verbose      bool
+	quiet        bool
+	changedOnly  bool
+	groupBy      string   // "" or "category"
+	format       string   // "text", "json", "junit", "tsv", "stat" (--stat), "metrics" (--metrics), or "results" (--json)
+	maxDetails   *int     // --max-details override; nil when not given
+	ref          string   // --ref; "" checks the working tree
+	color        bool     // ANSI colors and symbols; see useColor
+	depth        int      // -R: directory levels to search for repos
+	only         []string // --only: run just these checks; --staged defaults it to stagedChecks
+	staged       bool     // --staged: check what is staged for the next commit
+	skip         []string // --skip: leave out these checks
 }
 
 func lintRecursive(opts lintOptions) int {
-	entries, err := os.ReadDir(".")
+	repos, err := findRepos(".", opts.depth, opts.cfg.ExcludeDirs)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "error: %v\n", err)
-		return 2
+		return exitError
+	}
+
+	var report *jsonReport
+	if opts.format != "text" {
+		report = newJSONReport()
+	}
+	var page *jsonReport
+	if opts.htmlFile != "" {
+		page = newJSONReport()
 	}
 
-	exitCode := 0
+	exitCode := exitOK
+	found := 0
 	first := true
-	for _, entry := range entries {
-		if !entry.IsDir() {
-			continue
-		}
-		if _, err := os.Stat(filepath.Join(entry.Name(), ".git")); err != nil {
-			continue
-		}
+	summary := newRepoSummary()
+	fleetGap := opts.cfg.Thresholds.FleetStaleAfter.Duration
+	var ages []repoAge
+	for _, name := range repos {
+		found++
 
-		absDir, err := filepath.Abs(entry.Name())
+		absDir, err := filepath.Abs(name)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "error: %v\n", err)
-			if exitCode < 2 {
-				exitCode = 2
+			if exitCode < exitError {
+				exitCode = exitError
 			}
 			continue
 		}
 
 		results, code := runChecks(absDir, opts)
-		if code == 2 {
-			if exitCode < 2 {
-				exitCode = 2
+		if code >= exitError {
+			if exitCode < exitError {
+				exitCode = exitError
+			}
+			continue
+		}
+
+		if code > exitCode {
+			exitCode = code
+		}
+
+		if page != nil {
+			page.add(name, results)
+		}
+
+		if fleetGap > 0 {
+			if last, ok := headCommitTime(context.Background(), absDir); ok {
+				ages = append(ages, repoAge{Name: name, LastCommit: last})
 			}
+		}
+
+		if report != nil {
+			report.add(name, results)
 			continue
 		}
+		summary.add(name, results)
 
+		// --quiet and --changed-only both hide repos that are entirely ok
+		// (nothing failed, warned, or was fixed).
 		hasProblems := hasNonOK(results)
-		if opts.quiet && !hasProblems {
+		if (opts.quiet || opts.changedOnly) && !hasProblems {
 			continue
 		}
 
@@ -236,77 +526,331 @@ func lintRecursive(opts lintOptions) int {
 		}
 		first = false
 
-		if isTTY {
-			fmt.Printf("%s%s%s\n", ansiBold, entry.Name(), ansiReset)
+		if opts.color {
+			fmt.Printf("%s%s%s\n", ansiBold, name, ansiReset)
 		} else {
-			fmt.Printf("=== %s ===\n", entry.Name())
+			fmt.Printf("=== %s ===\n", name)
 		}
 
 		printResults(results, opts)
-		if code > exitCode {
-			exitCode = code
+	}
+
+	outliers, median := fleetOutliers(ages, fleetGap)
+	if report != nil {
+		report.Stalest = outliers
+		if err := report.writeFormat(os.Stdout, opts.format); err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			return exitError
+		}
+	} else {
+		if len(outliers) > 0 {
+			printFleetSummary(os.Stdout, outliers, median)
+		}
+		if !opts.quiet && summary.ReposChecked > 0 {
+			summary.print(os.Stdout)
 		}
 	}
 
-	if first {
-		if opts.quiet {
-			return exitCode
+	if page != nil {
+		if err := page.writeHTMLFile(opts.htmlFile); err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			return exitError
 		}
+	}
+
+	if found == 0 {
 		fmt.Fprintf(os.Stderr, "no git repos found\n")
-		return 2
+		return exitNoRepos
 	}
 	return exitCode
 }
 
 func lintRepo(dir string, opts lintOptions) int {
 	results, code := runChecks(dir, opts)
-	if code == 2 {
-		return 2
+	if code >= exitError {
+		return code
+	}
+	if opts.htmlFile != "" {
+		page := newJSONReport()
+		page.add(filepath.Base(dir), results)
+		if err := page.writeHTMLFile(opts.htmlFile); err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			return exitError
+		}
+	}
+	if opts.format != "text" {
+		if opts.format == "results" {
+			// A single repo's --json output is its bare results array.
+			if results == nil {
+				results = []Result{}
+			}
+			if err := writeJSON(os.Stdout, results); err != nil {
+				fmt.Fprintf(os.Stderr, "error: %v\n", err)
+				return exitError
+			}
+			return code
+		}
+		report := newJSONReport()
+		report.add(filepath.Base(dir), results)
+		if err := report.writeFormat(os.Stdout, opts.format); err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			return exitError
+		}
+		return code
 	}
 	printResults(results, opts)
 	return code
 }
 
-func runChecks(dir string, opts lintOptions) ([]Result, int) {
-	repo, err := NewRepo(dir, opts.cfg)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "error: %v\n", err)
-		if errors.Is(err, errNotARepo) {
-			fmt.Fprintf(os.Stderr, "hint: use -R to check each git repo in subdirectories\n")
-		}
-		return nil, 2
-	}
-
-	checks := []Check{
+// allChecks returns every check in the order they run. Order matters under
+// --fix: later checks see the layout earlier fixes produced.
+func allChecks() []Check {
+	return []Check{
 		&IdentityCheck{},
+		&GHAuthCheck{},
+		&ConfigOverrideCheck{},
+		&DefaultBranchCheck{},
+		&MailmapCheck{},
 		&ProtocolCheck{},
+		&PushProtocolCheck{},
 		&ForkSetupCheck{},
+		&ForkRenameCheck{},
+		&MissingUpstreamCheck{},
 		&RemoteCheck{},
+		&MainTrackingCheck{},
+		&OriginDefaultCheck{},
+		&RemoteHostCheck{},
+		&URLFormCheck{},
+		&CredentialHelperCheck{},
 		&AttributionCheck{},
 		&DependabotCheck{},
+		&ArchivedCheck{},
 		&HooksCheck{},
 		&ReviewsCheck{},
 		&StalenessCheck{},
+		&WorktreeCheck{},
+		&MergeHeadCheck{},
+		&InProgressCheck{},
+		&ReflogCheck{},
 		&SubmoduleCheck{},
+		&SuperprojectCheck{},
+		&SymlinkCheck{},
+		&FileModeCheck{},
+		&ExecBitCheck{},
+		&LargeFileCheck{},
+		&WhitespaceCheck{},
+		&RequiredTrackedCheck{},
+		&DependencyDirCheck{},
 		&BranchCleanupCheck{},
+		&BranchCaseCheck{},
+		&BranchNamingCheck{},
+		&RemoteBranchesCheck{},
 		&UnpushedCheck{},
+		&SigningCheck{},
+		&SigningKeyCheck{},
+		&CommitterCheck{},
+		&CommitIdentityCheck{},
+		&EmailLeakCheck{},
+		&SquashAuthorsCheck{},
+		&BehindCheck{},
+		&DivergedCheck{},
+		&TagCheck{},
 	}
+}
 
-	var allResults []Result
+// optInChecks are too slow or too specialized to run by default; the checks
+// config has to enable them.
+var optInChecks = map[string]bool{
+	"large-files": true,
+}
+
+// enabledChecks returns the checks the checks config does not turn off.
+// Checks missing from the config are enabled, except for optInChecks.
+func enabledChecks(checks []Check, config map[string]bool) []Check {
+	var enabled []Check
 	for _, c := range checks {
-		results := c.Check(repo)
-		if opts.fix {
-			results = c.Fix(repo, results)
+		on, ok := config[c.Name()]
+		if !ok {
+			on = !optInChecks[c.Name()]
+		}
+		if on {
+			enabled = append(enabled, c)
 		}
-		allResults = append(allResults, results...)
 	}
+	return enabled
+}
+
+// stagedChecks are the checks --staged runs unless --only picks others:
+// the ones that can still stop a bad commit before it is made.
+var stagedChecks = []string{"identity", "signing", "large-files"}
 
+// selectChecks applies --only and --skip to checks. With only, exactly the
+// named checks run, even ones the checks config turns off or that are
+// opt-in; otherwise the checks config decides. skip then drops the named
+// checks, which also trims the --staged set.
+func selectChecks(checks []Check, config map[string]bool, only, skip []string) []Check {
+	if len(only) == 0 {
+		checks = enabledChecks(checks, config)
+	}
+	var selected []Check
+	for _, c := range checks {
+		if (len(only) == 0 || slices.Contains(only, c.Name())) && !slices.Contains(skip, c.Name()) {
+			selected = append(selected, c)
+		}
+	}
+	return selected
+}
+
+// isCheckName reports whether name is the Name of one of allChecks.
+func isCheckName(name string) bool {
+	return slices.ContainsFunc(allChecks(), func(c Check) bool { return c.Name() == name })
+}
+
+func runChecks(dir string, opts lintOptions) ([]Result, int) {
+	repo, err := NewRepo(dir, opts.cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		if errors.Is(err, errNotARepo) {
+			fmt.Fprintf(os.Stderr, "hint: use -R to check each git repo in subdirectories\n")
+			return nil, exitNoRepos
+		}
+		return nil, exitError
+	}
+	repo.DryRun = opts.dryRun
+	repo.RefreshCache = opts.refreshCache
+	repo.Staged = opts.staged
+	if opts.ref != "" {
+		if _, err := repo.Git("rev-parse", "--verify", "--quiet", opts.ref+"^{commit}"); err != nil {
+			fmt.Fprintf(os.Stderr, "error: %s: unknown ref %q\n", dir, opts.ref)
+			return nil, exitError
+		}
+		repo.Ref = opts.ref
+	}
+
+	checks := selectChecks(allChecks(), opts.cfg.Checks, opts.only, opts.skip)
+
+	var allResults []Result
+	if opts.fix {
+		// Fixes mutate config and the working tree, and later checks must
+		// see the layout earlier fixes produced (e.g. fork adoption renames
+		// remotes before RemoteCheck runs), so check and fix sequentially.
+		for _, c := range checks {
+			results := c.Check(repo)
+			if !opts.dryRun {
+				allResults = append(allResults, withCheck(c, c.Fix(repo, results))...)
+				continue
+			}
+			// Drop cache writes the check itself skipped, so only the
+			// fix's changes show up.
+			repo.takeIntents()
+			fixed := c.Fix(repo, results)
+			allResults = append(allResults, withCheck(c, dryRunResults(fixed, repo.takeIntents()))...)
+		}
+	} else {
+		// Checks only read repo state, so they run concurrently. Results
+		// keep the registration order of the checks slice.
+		checkResults := make([][]Result, len(checks))
+		var wg sync.WaitGroup
+		for i, c := range checks {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				checkResults[i] = withCheck(c, c.Check(repo))
+			}()
+		}
+		wg.Wait()
+		for _, results := range checkResults {
+			allResults = append(allResults, results...)
+		}
+	}
+
+	if opts.fix && !opts.dryRun {
+		allResults = append(allResults, fixSummary(repo.touchedPaths())...)
+	}
+	allResults = append(allResults, repo.TimeoutResults()...)
 	allResults = suppressRedundantTracking(allResults)
+	allResults = applyIgnores(allResults, repo.IgnoredResults(), opts.verbose)
+	if opts.cfg.State && !opts.dryRun {
+		ran := make(map[string]bool, len(checks))
+		for _, c := range checks {
+			ran[c.Name()] = true
+		}
+		recordFirstSeen(repo.Dir, allResults, ran)
+	}
 
-	if hasFailures(allResults) {
-		return allResults, 1
+	if hasFailures(allResults) || (opts.cfg.WarningsAsErrors && hasWarnings(allResults)) {
+		return allResults, exitFindings
 	}
-	return allResults, 0
+	return allResults, exitOK
+}
+
+// fixSummary reports the files --fix changed, as an audit trail for a
+// working tree or config that looks different afterwards.
+func fixSummary(paths []string) []Result {
+	if len(paths) == 0 {
+		return nil
+	}
+	msg := fmt.Sprintf("modified %d files", len(paths))
+	if len(paths) == 1 {
+		msg = "modified 1 file"
+	}
+	return []Result{{
+		Name:    "fix/summary",
+		Status:  StatusFix,
+		Message: msg,
+		Details: paths,
+	}}
+}
+
+// withCheck sets the Check field of results to c's name.
+func withCheck(c Check, results []Result) []Result {
+	for i := range results {
+		results[i].Check = c.Name()
+	}
+	return results
+}
+
+// dryRunResults rewords the fixes a --fix-dry-run pass pretended to apply:
+// each fix message, worded in the past tense, gets a "would have" prefix,
+// and the changes the fix recorded become its detail lines. When one check
+// fixed several results, each gets the changes that mention its parameter
+// (a branch or remote name).
+func dryRunResults(results []Result, intents []string) []Result {
+	var fixes int
+	for _, r := range results {
+		if r.Status == StatusFix {
+			fixes++
+		}
+	}
+	out := make([]Result, len(results))
+	for i, r := range results {
+		if r.Status == StatusFix {
+			r.Message = "would have " + r.Message
+			if fixes == 1 {
+				r.Details = intents
+			} else if _, param := splitResultName(r.Name); param != "" {
+				r.Details = nil
+				for _, intent := range intents {
+					if mentions(intent, param) {
+						r.Details = append(r.Details, intent)
+					}
+				}
+			}
+		}
+		out[i] = r
+	}
+	return out
+}
+
+// mentions reports whether one of intent's words is name, or a path or ref
+// ending in it, like "refs/heads/name".
+func mentions(intent, name string) bool {
+	for _, word := range strings.Fields(intent) {
+		if word == name || strings.HasSuffix(word, "/"+name) {
+			return true
+		}
+	}
+	return false
 }
 
 // suppressRedundantTracking drops remote/branch-tracking warnings for branches
@@ -317,7 +861,8 @@ func suppressRedundantTracking(results []Result) []Result {
 	flaggedForCleanup := make(map[string]bool)
 	for _, r := range results {
 		rule, branch := splitResultName(r.Name)
-		if branch != "" && strings.HasPrefix(rule, "branch/") {
+		// branch/naming flags a name, not a branch to delete.
+		if branch != "" && strings.HasPrefix(rule, "branch/") && rule != "branch/naming" {
 			flaggedForCleanup[branch] = true
 		}
 	}
@@ -336,6 +881,30 @@ func suppressRedundantTracking(results []Result) []Result {
 	return filtered
 }
 
+// applyIgnores drops results whose exact name (including any [param]) the
+// repo's lint.ignore config lists. With verbose, ignored results stay in the
+// output as ok, marked "ignored", so suppressed findings remain visible.
+func applyIgnores(results []Result, ignored map[string]bool, verbose bool) []Result {
+	if len(ignored) == 0 {
+		return results
+	}
+	var filtered []Result
+	for _, r := range results {
+		if !ignored[r.Name] || r.Status == StatusOK {
+			filtered = append(filtered, r)
+			continue
+		}
+		if verbose {
+			filtered = append(filtered, Result{
+				Name:    r.Name,
+				Status:  StatusOK,
+				Message: "ignored: " + r.Message,
+			})
+		}
+	}
+	return filtered
+}
+
 func printResults(results []Result, opts lintOptions) {
 	// Determine how many detail lines to show per result.
 	// -1 = unlimited (--verbose), 0 = none (--quiet), >0 = configured limit.
@@ -349,19 +918,40 @@ func printResults(results []Result, opts lintOptions) {
 	if opts.verbose {
 		detailLimit = -1
 	}
+	if opts.maxDetails != nil {
+		detailLimit = *opts.maxDetails
+	}
 
 	hasProblems := false
+	var shown []Result
 	for _, r := range results {
 		if r.Status != StatusOK {
 			hasProblems = true
 		}
 		if opts.verbose || r.Status != StatusOK {
-			printResult(r, detailLimit, opts.verbose)
+			shown = append(shown, r)
+		}
+	}
+
+	if opts.groupBy == "category" {
+		for _, g := range groupByCategory(shown) {
+			if opts.color {
+				fmt.Printf("%s%s%s %s(%d)%s\n", ansiBold, g.category, ansiReset, ansiDim, len(g.results), ansiReset)
+			} else {
+				fmt.Printf("--- %s (%d) ---\n", g.category, len(g.results))
+			}
+			for _, r := range g.results {
+				printResult(r, detailLimit, opts)
+			}
+		}
+	} else {
+		for _, r := range shown {
+			printResult(r, detailLimit, opts)
 		}
 	}
 
 	if !hasProblems {
-		if isTTY {
+		if opts.color {
 			fmt.Printf("%s✓ repo ok%s\n", ansiGreen, ansiReset)
 		} else {
 			fmt.Println("repo ok")
@@ -369,6 +959,40 @@ func printResults(results []Result, opts lintOptions) {
 	}
 }
 
+type resultGroup struct {
+	category string
+	results  []Result
+}
+
+// groupByCategory buckets results by the category prefix of their rule
+// ("branch" for "branch/merged[old]"), keeping categories in order of first
+// appearance and results in their original order within each category.
+func groupByCategory(results []Result) []resultGroup {
+	var groups []resultGroup
+	index := make(map[string]int)
+	for _, r := range results {
+		cat := resultCategory(r.Name)
+		i, ok := index[cat]
+		if !ok {
+			i = len(groups)
+			index[cat] = i
+			groups = append(groups, resultGroup{category: cat})
+		}
+		groups[i].results = append(groups[i].results, r)
+	}
+	return groups
+}
+
+// resultCategory returns the category of a result name: the part of the
+// rule before the first slash, e.g. "staleness" for "staleness/unpushed[bats]".
+func resultCategory(name string) string {
+	rule, _ := splitResultName(name)
+	if i := strings.IndexByte(rule, '/'); i >= 0 {
+		return rule[:i]
+	}
+	return rule
+}
+
 func hasNonOK(results []Result) bool {
 	for _, r := range results {
 		if r.Status != StatusOK {
@@ -378,9 +1002,9 @@ func hasNonOK(results []Result) bool {
 	return false
 }
 
-func printResult(r Result, detailLimit int, verbose bool) {
-	if isTTY {
-		printResultTTY(r, verbose)
+func printResult(r Result, detailLimit int, opts lintOptions) {
+	if opts.color {
+		printResultTTY(r, opts.verbose)
 	} else {
 		fix := ""
 		if r.Fixable && r.Status == StatusWarn {
@@ -397,14 +1021,14 @@ func printResult(r Result, detailLimit int, verbose bool) {
 		show = detailLimit
 	}
 	for _, d := range r.Details[:show] {
-		if isTTY {
+		if opts.color {
 			fmt.Printf("  %s%s%s\n", ansiDim, d, ansiReset)
 		} else {
 			fmt.Printf("      %s\n", d)
 		}
 	}
 	if remaining := len(r.Details) - show; remaining > 0 {
-		if isTTY {
+		if opts.color {
 			fmt.Printf("  %s...and %d more%s\n", ansiDim, remaining, ansiReset)
 		} else {
 			fmt.Printf("      ...and %d more\n", remaining)
@@ -477,3 +1101,12 @@ func hasFailures(results []Result) bool {
 	}
 	return false
 }
+
+func hasWarnings(results []Result) bool {
+	for _, r := range results {
+		if r.Status == StatusWarn {
+			return true
+		}
+	}
+	return false
+}
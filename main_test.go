@@ -1,6 +1,12 @@
 package main
 
 import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 )
@@ -89,3 +95,345 @@ func TestApplyFlagsIgnoresInvalidDuration(t *testing.T) {
 		t.Errorf("StashMaxAge = %v, want 0 (invalid input ignored)", cfg.Thresholds.StashMaxAge.Duration)
 	}
 }
+
+func TestGroupByCategory(t *testing.T) {
+	groups := groupByCategory([]Result{
+		{Name: "branch/merged[a]"},
+		{Name: "identity/email"},
+		{Name: "branch/gone[b]"},
+		{Name: "staleness/unpushed[c]"},
+	})
+	want := []struct {
+		category string
+		count    int
+	}{{"branch", 2}, {"identity", 1}, {"staleness", 1}}
+	if len(groups) != len(want) {
+		t.Fatalf("got %d groups, want %d: %+v", len(groups), len(want), groups)
+	}
+	for i, w := range want {
+		if groups[i].category != w.category || len(groups[i].results) != w.count {
+			t.Errorf("group %d = %s (%d), want %s (%d)",
+				i, groups[i].category, len(groups[i].results), w.category, w.count)
+		}
+	}
+	if groups[0].results[1].Name != "branch/gone[b]" {
+		t.Errorf("results within a category should keep their order; got %+v", groups[0].results)
+	}
+}
+
+func TestRunChecksKeepsRegistrationOrder(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("a.txt", "a", "first", time.Now())
+
+	results, _ := runChecks(r.dir, lintOptions{cfg: r.Config})
+	identity, staleness := -1, -1
+	for i, res := range results {
+		switch res.Name {
+		case "identity/name":
+			identity = i
+		case "staleness/uncommitted":
+			staleness = i
+		}
+	}
+	if identity < 0 || staleness < 0 || identity > staleness {
+		t.Errorf("identity/name at %d, staleness/uncommitted at %d; want identity first", identity, staleness)
+	}
+}
+
+func TestApplyIgnores(t *testing.T) {
+	results := []Result{
+		{Name: "branch/orphan[demo]", Status: StatusWarn, Message: "no upstream"},
+		{Name: "branch/orphan[other]", Status: StatusWarn},
+	}
+	ignored := map[string]bool{"branch/orphan[demo]": true}
+
+	got := applyIgnores(results, ignored, false)
+	if _, ok := resultByName(got, "branch/orphan[demo]"); ok {
+		t.Error("ignored result should be dropped")
+	}
+	if _, ok := resultByName(got, "branch/orphan[other]"); !ok {
+		t.Error("other results should be kept")
+	}
+
+	got = applyIgnores(results, ignored, true)
+	if r, ok := resultByName(got, "branch/orphan[demo]"); !ok || r.Status != StatusOK || r.Message != "ignored: no upstream" {
+		t.Errorf("verbose: ignored result = %+v, want ok marked ignored", r)
+	}
+}
+
+func TestPreflight(t *testing.T) {
+	if err := preflight(false); err != nil {
+		t.Fatalf("preflight with git on PATH: %v", err)
+	}
+	t.Setenv("PATH", t.TempDir())
+	if err := preflight(false); err == nil || err.Error() != "git not found on PATH" {
+		t.Errorf("preflight without git = %v, want git not found", err)
+	}
+}
+
+// captureStdout returns what fn prints to stdout.
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	orig := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = orig }()
+	fn()
+	w.Close()
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(out)
+}
+
+func TestPrintResultsColor(t *testing.T) {
+	results := []Result{{Name: "branch/merged[old]", Status: StatusWarn, Message: "merged", Fixable: true}}
+	opts := lintOptions{cfg: &Config{}}
+
+	plain := captureStdout(t, func() { printResults(results, opts) })
+	if want := "warn branch/merged[old]       merged [--fix]\n"; plain != want {
+		t.Errorf("plain output = %q, want %q", plain, want)
+	}
+
+	opts.color = true
+	colored := captureStdout(t, func() { printResults(results, opts) })
+	if !strings.Contains(colored, ansiCyan) {
+		t.Errorf("colored output = %q, want ANSI codes", colored)
+	}
+}
+
+func TestPrintResultsMaxDetails(t *testing.T) {
+	results := []Result{{Name: "staleness/untracked", Status: StatusWarn, Message: "3 untracked files",
+		Details: []string{"a", "b", "c"}}}
+	intp := func(n int) *int { return &n }
+	tests := []struct {
+		name       string
+		opts       lintOptions
+		wantShown  int
+		wantRemain int
+	}{
+		{"default", lintOptions{}, 3, 0},
+		{"quiet", lintOptions{quiet: true}, 0, 0},
+		{"verbose", lintOptions{verbose: true}, 3, 0},
+		{"max-details", lintOptions{maxDetails: intp(2)}, 2, 1},
+		{"max-details over verbose", lintOptions{verbose: true, maxDetails: intp(1)}, 1, 2},
+		{"max-details over quiet", lintOptions{quiet: true, maxDetails: intp(2)}, 2, 1},
+		{"max-details unlimited over quiet", lintOptions{quiet: true, maxDetails: intp(-1)}, 3, 0},
+		{"max-details none over verbose", lintOptions{verbose: true, maxDetails: intp(0)}, 0, 0},
+	}
+	for _, tt := range tests {
+		tt.opts.cfg = &Config{}
+		out := captureStdout(t, func() { printResults(results, tt.opts) })
+		shown, remain := 0, 0
+		for _, line := range strings.Split(out, "\n") {
+			if rest, ok := strings.CutPrefix(line, "      "); ok {
+				if _, err := fmt.Sscanf(rest, "...and %d more", &remain); err != nil {
+					shown++
+				}
+			}
+		}
+		if shown != tt.wantShown || remain != tt.wantRemain {
+			t.Errorf("%s: %d details and %d more, want %d and %d\n%s",
+				tt.name, shown, remain, tt.wantShown, tt.wantRemain, out)
+		}
+	}
+}
+
+func TestUseColorHonorsNoColor(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	if useColor(false) {
+		t.Error("useColor with NO_COLOR set = true, want false")
+	}
+	t.Setenv("NO_COLOR", "")
+	if useColor(true) {
+		t.Error("useColor with --no-color = true, want false")
+	}
+}
+
+func TestCheckNamesUnique(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, c := range allChecks() {
+		name := c.Name()
+		if name == "" || seen[name] {
+			t.Errorf("check %T has empty or duplicate name %q", c, name)
+		}
+		seen[name] = true
+	}
+}
+
+func TestEnabledChecks(t *testing.T) {
+	all := allChecks()
+	got := enabledChecks(all, map[string]bool{"attribution": false, "submodules": true})
+	if len(got) != len(all)-1-len(optInChecks) {
+		t.Fatalf("enabledChecks kept %d of %d checks, want all but one", len(got), len(all))
+	}
+	for _, c := range got {
+		if c.Name() == "attribution" {
+			t.Error("attribution still enabled")
+		}
+	}
+	if got := enabledChecks(all, nil); len(got) != len(all)-len(optInChecks) {
+		t.Errorf("no config: kept %d of %d checks, want all but the opt-in ones", len(got), len(all))
+	}
+	got = enabledChecks(all, map[string]bool{"large-files": true})
+	if !slices.ContainsFunc(got, func(c Check) bool { return c.Name() == "large-files" }) {
+		t.Error("opt-in large-files not enabled by the checks config")
+	}
+}
+
+func TestSelectChecks(t *testing.T) {
+	all := allChecks()
+	names := func(checks []Check) []string {
+		var out []string
+		for _, c := range checks {
+			out = append(out, c.Name())
+		}
+		return out
+	}
+
+	// --only runs exactly the named checks, even disabled and opt-in ones.
+	got := names(selectChecks(all, map[string]bool{"identity": false}, []string{"large-files", "identity"}, nil))
+	if want := []string{"identity", "large-files"}; !slices.Equal(got, want) {
+		t.Errorf("--only = %q, want %q", got, want)
+	}
+
+	got = names(selectChecks(all, map[string]bool{"attribution": false}, nil, []string{"identity"}))
+	if slices.Contains(got, "identity") || slices.Contains(got, "attribution") || slices.Contains(got, "large-files") {
+		t.Errorf("--skip identity = %q, want identity, attribution, and large-files left out", got)
+	}
+	if len(got) != len(all)-2-len(optInChecks) {
+		t.Errorf("--skip identity kept %d of %d checks, want all but three", len(got), len(all))
+	}
+
+	// --staged narrows to its checks through only, which skip can trim.
+	got = names(selectChecks(all, nil, stagedChecks, []string{"signing"}))
+	if want := []string{"identity", "large-files"}; !slices.Equal(got, want) {
+		t.Errorf("--staged --skip signing = %q, want %q", got, want)
+	}
+
+	if !isCheckName("identity") || isCheckName("identity/email") {
+		t.Error("isCheckName does not match check names exactly")
+	}
+}
+
+func TestRunChecksWarningsAsErrors(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("a.txt", "a", "first", time.Now())
+	// A stale MERGE_HEAD is a warning, not a failure.
+	head := r.git("rev-parse", "HEAD")
+	if err := os.WriteFile(filepath.Join(r.dir, ".git", "MERGE_HEAD"), []byte(head+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	results, code := runChecks(r.dir, lintOptions{cfg: r.Config})
+	if hasFailures(results) || !hasWarnings(results) {
+		t.Fatalf("want warnings and no failures, got %+v", results)
+	}
+	if got, _ := resultByName(results, "state/merge-head"); got.Check != "merge-head" {
+		t.Errorf("state/merge-head reported by check %q, want merge-head", got.Check)
+	}
+	if code != exitOK {
+		t.Errorf("exit code = %d, want %d", code, exitOK)
+	}
+
+	r.Config.WarningsAsErrors = true
+	if _, code := runChecks(r.dir, lintOptions{cfg: r.Config}); code != exitFindings {
+		t.Errorf("with warningsAsErrors: exit code = %d, want %d", code, exitFindings)
+	}
+}
+
+// newRepoTree creates a clean repo "clean" and a repo "dirty" with a stale
+// MERGE_HEAD under a new directory and changes into it, as -R would see it.
+func newRepoTree(t *testing.T) *Config {
+	t.Helper()
+	cfg := newTestRepo(t).Config // isolates git from the host config
+	root := t.TempDir()
+	for _, name := range []string{"clean", "dirty"} {
+		runGit(t, root, nil, "init", "-q", "--initial-branch=main", name)
+		runGit(t, filepath.Join(root, name), nil,
+			"-c", "user.name=Test User", "-c", "user.email=test@example.com",
+			"commit", "-q", "--allow-empty", "-m", "first")
+	}
+	head := runGit(t, filepath.Join(root, "dirty"), nil, "rev-parse", "HEAD")
+	if err := os.WriteFile(filepath.Join(root, "dirty", ".git", "MERGE_HEAD"), []byte(head+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(root)
+	return cfg
+}
+
+func TestLintRecursiveChangedOnly(t *testing.T) {
+	cfg := newRepoTree(t)
+	tests := []struct {
+		name        string
+		changedOnly bool
+		wantClean   bool
+	}{
+		{"all repos", false, true},
+		{"changed only", true, false},
+	}
+	for _, tt := range tests {
+		opts := lintOptions{cfg: cfg, format: "text", depth: 1, only: []string{"merge-head"}, changedOnly: tt.changedOnly}
+		out := captureStdout(t, func() { lintRecursive(opts) })
+		if !strings.Contains(out, "=== dirty ===") {
+			t.Errorf("%s: dirty repo missing from output:\n%s", tt.name, out)
+		}
+		if got := strings.Contains(out, "=== clean ==="); got != tt.wantClean {
+			t.Errorf("%s: clean repo listed = %v, want %v:\n%s", tt.name, got, tt.wantClean, out)
+		}
+	}
+}
+
+func TestExitNoRepos(t *testing.T) {
+	cfg := &Config{}
+	tests := []struct {
+		name string
+		run  func() int
+		want int
+	}{
+		{"-R without repos", func() int {
+			t.Chdir(t.TempDir())
+			return lintRecursive(lintOptions{cfg: cfg, format: "text", depth: 1})
+		}, exitNoRepos},
+		{"not a repo", func() int {
+			return lintRepo(t.TempDir(), lintOptions{cfg: cfg, format: "text"})
+		}, exitNoRepos},
+		{"-R with repos", func() int {
+			cfg := newRepoTree(t)
+			return lintRecursive(lintOptions{cfg: cfg, format: "text", depth: 1, only: []string{"merge-head"}})
+		}, exitOK},
+	}
+	for _, tt := range tests {
+		var got int
+		captureStdout(t, func() { got = tt.run() })
+		if got != tt.want {
+			t.Errorf("%s: exit code = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRunChecksFixSummary(t *testing.T) {
+	r := newTestRepo(t)
+	r.git("remote", "add", "origin", "git@github.com:acme/repo.git")
+	r.Config.WorkOrgs = []string{"acme"}
+
+	results, _ := runChecks(r.dir, lintOptions{cfg: r.Config, fix: true, only: []string{"attribution"}})
+	got, ok := resultByName(results, "fix/summary")
+	if !ok || got.Status != StatusFix {
+		t.Fatalf("fix/summary = %+v, want a fix result", results)
+	}
+	want := []string{".claude/settings.local.json", ".git/config", ".git/info/exclude"}
+	if !slices.Equal(got.Details, want) {
+		t.Errorf("touched paths = %q, want %q", got.Details, want)
+	}
+
+	// Nothing left to fix, so nothing to summarize.
+	results, _ = runChecks(r.dir, lintOptions{cfg: r.Config, fix: true, only: []string{"attribution"}})
+	if got, ok := resultByName(results, "fix/summary"); ok {
+		t.Errorf("second --fix run: fix/summary = %+v, want none", got)
+	}
+}
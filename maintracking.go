@@ -0,0 +1,73 @@
+package main
+
+import "fmt"
+
+// MainTrackingCheck warns when the main branch has no upstream although the
+// repo has remotes, so a plain git pull on main fails. Repos with an upstream
+// remote are left to RemoteCheck, which wants main to track upstream; this
+// covers simple clones. The fix sets main to track origin/<main> when that
+// remote branch exists.
+type MainTrackingCheck struct{}
+
+func (c *MainTrackingCheck) Name() string { return "main-tracking" }
+
+func (c *MainTrackingCheck) Check(repo *Repo) []Result {
+	remotes, _ := repo.Remotes()
+	if len(remotes) == 0 || hasRemote(remotes, "upstream") {
+		return nil
+	}
+	mainBranch := repo.MainBranch()
+	if mainBranch == "" {
+		return nil
+	}
+
+	if remote := repo.GitConfig(fmt.Sprintf("branch.%s.remote", mainBranch)); remote != "" {
+		return []Result{{
+			Name:    "remote/main-tracking",
+			Status:  StatusOK,
+			Message: fmt.Sprintf("%s tracks %s", mainBranch, remote),
+		}}
+	}
+	if originHasBranch(repo, mainBranch) {
+		return []Result{{
+			Name:    "remote/main-tracking",
+			Status:  StatusWarn,
+			Message: fmt.Sprintf("%s has no upstream; git pull fails", mainBranch),
+			Fixable: true,
+		}}
+	}
+	return []Result{{
+		Name:    "remote/main-tracking",
+		Status:  StatusWarn,
+		Message: fmt.Sprintf("%s has no upstream, and there is no origin/%s to track", mainBranch, mainBranch),
+	}}
+}
+
+func (c *MainTrackingCheck) Fix(repo *Repo, results []Result) []Result {
+	var fixed []Result
+	for _, r := range results {
+		if r.Status != StatusWarn || !r.Fixable {
+			fixed = append(fixed, r)
+			continue
+		}
+		mainBranch := repo.MainBranch()
+		upstream := "origin/" + mainBranch
+		if _, err := repo.Git("branch", "--set-upstream-to="+upstream, mainBranch); err != nil {
+			fixed = append(fixed, r)
+			continue
+		}
+		fixed = append(fixed, Result{
+			Name:    r.Name,
+			Status:  StatusFix,
+			Message: fmt.Sprintf("set %s to track %s", mainBranch, upstream),
+		})
+	}
+	return fixed
+}
+
+// originHasBranch reports whether the remote-tracking ref origin/<branch>
+// exists locally.
+func originHasBranch(repo *Repo, branch string) bool {
+	_, err := repo.Git("rev-parse", "--verify", "--quiet", "refs/remotes/origin/"+branch)
+	return err == nil
+}
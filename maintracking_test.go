@@ -0,0 +1,44 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMainTrackingCheck(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("a.txt", "a", "first", time.Now())
+	if results := (&MainTrackingCheck{}).Check(r.Repo); len(results) != 0 {
+		t.Errorf("no remotes: got %+v, want none", results)
+	}
+
+	r.git("remote", "add", "origin", "https://github.com/me/repo.git")
+	r.reload()
+	got, _ := resultByName((&MainTrackingCheck{}).Check(r.Repo), "remote/main-tracking")
+	if got.Status != StatusWarn || got.Fixable {
+		t.Errorf("without origin/main = %+v, want non-fixable warn", got)
+	}
+
+	r.git("update-ref", "refs/remotes/origin/main", "HEAD")
+	results := (&MainTrackingCheck{}).Check(r.Repo)
+	if got, _ := resultByName(results, "remote/main-tracking"); got.Status != StatusWarn || !got.Fixable {
+		t.Fatalf("with origin/main = %+v, want fixable warn", got)
+	}
+	fixed := (&MainTrackingCheck{}).Fix(r.Repo, results)
+	if got, _ := resultByName(fixed, "remote/main-tracking"); got.Status != StatusFix {
+		t.Errorf("after fix = %+v, want fix", got)
+	}
+	if v := r.git("rev-parse", "--abbrev-ref", "main@{upstream}"); v != "origin/main" {
+		t.Errorf("main@{upstream} = %q, want origin/main", v)
+	}
+	if got, _ := resultByName((&MainTrackingCheck{}).Check(r.Repo), "remote/main-tracking"); got.Status != StatusOK {
+		t.Errorf("re-check = %+v, want ok", got)
+	}
+
+	// An upstream remote hands main's tracking over to RemoteCheck.
+	r.git("remote", "add", "upstream", "https://github.com/acme/repo.git")
+	r.reload()
+	if results := (&MainTrackingCheck{}).Check(r.Repo); len(results) != 0 {
+		t.Errorf("with upstream remote: got %+v, want none", results)
+	}
+}
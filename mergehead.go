@@ -0,0 +1,95 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// MergeHeadCheck detects a leftover MERGE_HEAD when no merge is actually in
+// progress, e.g. after an interrupted or half-aborted merge. Editors and
+// prompts read MERGE_HEAD to show a "merging" state, so a stale one is
+// misleading. A MERGE_HEAD counts as stale only when the index has no
+// unmerged entries and every merged commit is already reachable from HEAD,
+// so removing it never drops a genuine merge.
+type MergeHeadCheck struct{}
+
+func (c *MergeHeadCheck) Name() string { return "merge-head" }
+
+func (c *MergeHeadCheck) Check(repo *Repo) []Result {
+	if repo.Ref != "" {
+		return refNotApplicable("state/merge-head")
+	}
+	mergeHeadPath := gitPath(repo, "MERGE_HEAD")
+	data, err := os.ReadFile(mergeHeadPath)
+	if err != nil {
+		return nil
+	}
+	if !mergeHeadStale(repo, strings.Fields(string(data))) {
+		return nil
+	}
+	return []Result{{
+		Name:    "state/merge-head",
+		Status:  StatusWarn,
+		Message: "stale MERGE_HEAD: no merge in progress",
+		Fixable: true,
+	}}
+}
+
+func (c *MergeHeadCheck) Fix(repo *Repo, results []Result) []Result {
+	var fixed []Result
+	for _, r := range results {
+		if !r.Fixable || r.Name != "state/merge-head" {
+			fixed = append(fixed, r)
+			continue
+		}
+		failed := false
+		for _, name := range []string{"MERGE_HEAD", "MERGE_MSG", "MERGE_MODE"} {
+			if err := repo.RemoveFile(gitPath(repo, name)); err != nil {
+				failed = true
+			}
+		}
+		if failed {
+			fixed = append(fixed, r)
+			continue
+		}
+		fixed = append(fixed, Result{
+			Name:    r.Name,
+			Status:  StatusFix,
+			Message: "removed stale MERGE_HEAD",
+		})
+	}
+	return fixed
+}
+
+// mergeHeadStale reports whether a MERGE_HEAD listing the given commits
+// describes no real merge: nothing is unmerged and all commits are already
+// ancestors of HEAD.
+func mergeHeadStale(repo *Repo, commits []string) bool {
+	if len(commits) == 0 {
+		return true
+	}
+	unmerged, err := repo.Git("ls-files", "--unmerged")
+	if err != nil || unmerged != "" {
+		return false
+	}
+	for _, sha := range commits {
+		if _, err := repo.Git("merge-base", "--is-ancestor", sha, "HEAD"); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// gitPath returns the absolute path of a file inside the repo's git
+// directory, resolving worktree and GIT_DIR layouts.
+func gitPath(repo *Repo, name string) string {
+	p, err := repo.Git("rev-parse", "--git-path", name)
+	if err != nil || p == "" {
+		return filepath.Join(repo.Dir, ".git", name)
+	}
+	if !filepath.IsAbs(p) {
+		p = filepath.Join(repo.Dir, p)
+	}
+	return p
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMergeHeadStale(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("a.txt", "a", "first", time.Now())
+	merged := r.git("rev-parse", "HEAD")
+	r.commit("b.txt", "b", "second", time.Now())
+
+	// MERGE_HEAD names a commit HEAD already contains: stale.
+	mergeHead := filepath.Join(r.dir, ".git", "MERGE_HEAD")
+	if err := os.WriteFile(mergeHead, []byte(merged+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	results := (&MergeHeadCheck{}).Check(r.Repo)
+	got, ok := resultByName(results, "state/merge-head")
+	if !ok || got.Status != StatusWarn || !got.Fixable {
+		t.Fatalf("state/merge-head = %+v, want fixable warn", results)
+	}
+
+	fixed := (&MergeHeadCheck{}).Fix(r.Repo, results)
+	if got, _ := resultByName(fixed, "state/merge-head"); got.Status != StatusFix {
+		t.Errorf("after fix = %+v, want fix", got)
+	}
+	if _, err := os.Stat(mergeHead); !os.IsNotExist(err) {
+		t.Errorf("MERGE_HEAD still present: %v", err)
+	}
+}
+
+func TestMergeHeadGenuineMergeUntouched(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("a.txt", "a", "first", time.Now())
+	r.git("checkout", "-b", "feature")
+	r.commit("b.txt", "b", "feature work", time.Now())
+	r.git("checkout", "main")
+	r.commit("c.txt", "c", "main work", time.Now())
+	r.git("merge", "--no-commit", "--no-ff", "feature")
+
+	if results := (&MergeHeadCheck{}).Check(r.Repo); len(results) != 0 {
+		t.Errorf("in-progress merge flagged: %+v", results)
+	}
+}
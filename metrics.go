@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// writeMetrics writes the report for --metrics in the Prometheus text
+// exposition format, for a node exporter textfile collector: the summary
+// counts, and the failed and warned results per rule (the result name
+// without its [param]) across all repos.
+func (rep *jsonReport) writeMetrics(w io.Writer) error {
+	failures := map[string]int{}
+	warnings := map[string]int{}
+	for _, repo := range rep.Repos {
+		for _, r := range repo.Results {
+			rule, _ := splitResultName(r.Name)
+			switch r.Status {
+			case StatusFail:
+				failures[rule]++
+			case StatusWarn:
+				warnings[rule]++
+			}
+		}
+	}
+
+	var b strings.Builder
+	s := rep.Summary
+	for _, m := range []struct {
+		name, help string
+		value      int
+	}{
+		{"git_lint_repos_checked", "Repositories checked.", s.ReposChecked},
+		{"git_lint_repos_ok", "Repositories with no warnings or failures.", s.ReposOK},
+		{"git_lint_repos_warned", "Repositories with warnings but no failures.", s.ReposWarned},
+		{"git_lint_repos_failed", "Repositories with failures.", s.ReposFailed},
+	} {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s gauge\n%s %d\n", m.name, m.help, m.name, m.name, m.value)
+	}
+	writeRuleMetric(&b, "git_lint_rule_failures", "Failed results per rule across all repositories.", failures)
+	writeRuleMetric(&b, "git_lint_rule_warnings", "Warnings per rule across all repositories.", warnings)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeRuleMetric writes one gauge family with a sample per rule, sorted by
+// rule. A family without samples is left out.
+func writeRuleMetric(b *strings.Builder, name, help string, counts map[string]int) {
+	if len(counts) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+	for _, rule := range slices.Sorted(maps.Keys(counts)) {
+		fmt.Fprintf(b, "%s{rule=\"%s\"} %d\n", name, escapeLabelValue(rule), counts[rule])
+	}
+}
+
+// escapeLabelValue escapes a Prometheus label value: backslash, double
+// quote, and newline.
+func escapeLabelValue(v string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(v)
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestMetricsReport(t *testing.T) {
+	rep := newJSONReport()
+	rep.add("clean", []Result{{Name: "identity/name", Status: StatusOK}})
+	rep.add("warned", []Result{{Name: "branch/merged[x]", Status: StatusWarn}, {Name: "branch/merged[y]", Status: StatusWarn}})
+	rep.add("failed", []Result{{Name: "identity/email", Status: StatusFail}})
+
+	var buf bytes.Buffer
+	if err := rep.writeFormat(&buf, "metrics"); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"# TYPE git_lint_repos_checked gauge\ngit_lint_repos_checked 3\n",
+		"git_lint_repos_ok 1\n",
+		"git_lint_repos_warned 1\n",
+		"git_lint_repos_failed 1\n",
+		"git_lint_rule_failures{rule=\"identity/email\"} 1\n",
+		"git_lint_rule_warnings{rule=\"branch/merged\"} 2\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("metrics missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestEscapeLabelValue(t *testing.T) {
+	if got, want := escapeLabelValue("a\"b\\c\nd"), `a\"b\\c\nd`; got != want {
+		t.Errorf("escapeLabelValue = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OriginDefaultCheck compares the repo's main branch with origin's default
+// branch, which drift apart when origin renames its default (master to
+// main) after the clone. It reads the local origin/HEAD symref, or with
+// online set asks origin, since origin/HEAD itself is not updated by fetch.
+// The fix points origin/HEAD at the new default and, when no local branch
+// has the new name yet, renames the local main branch to it and makes it
+// track origin's.
+type OriginDefaultCheck struct{}
+
+func (c *OriginDefaultCheck) Name() string { return "origin-default" }
+
+func (c *OriginDefaultCheck) Check(repo *Repo) []Result {
+	mainBranch := repo.MainBranch()
+	if mainBranch == "" || !repo.hasRemoteNamed("origin") {
+		return nil
+	}
+	def := originDefaultBranch(repo)
+	if def == "" {
+		return nil
+	}
+	if def == mainBranch {
+		return []Result{{
+			Name:    "remote/default-branch",
+			Status:  StatusOK,
+			Message: fmt.Sprintf("main branch %s is origin's default", mainBranch),
+		}}
+	}
+	return []Result{{
+		Name:    "remote/default-branch",
+		Status:  StatusWarn,
+		Message: fmt.Sprintf("origin's default branch is %s, but the local main branch is %s", def, mainBranch),
+		Fixable: true,
+	}}
+}
+
+func (c *OriginDefaultCheck) Fix(repo *Repo, results []Result) []Result {
+	var fixed []Result
+	for _, r := range results {
+		if !r.Fixable || r.Name != "remote/default-branch" {
+			fixed = append(fixed, r)
+			continue
+		}
+		mainBranch := repo.MainBranch()
+		def := originDefaultBranch(repo)
+		if def == "" || !repo.hasRemoteBranch("origin", def) {
+			r.Details = append(r.Details, fmt.Sprintf("origin/%s not fetched; run git fetch origin first", def))
+			fixed = append(fixed, r)
+			continue
+		}
+		if _, err := repo.Git("remote", "set-head", "origin", def); err != nil {
+			fixed = append(fixed, r)
+			continue
+		}
+		msg := fmt.Sprintf("set origin/HEAD to origin/%s", def)
+		if !repo.hasLocalBranch(def) {
+			// The rename carries the branch's config along. A fork's main
+			// tracks upstream with pushes disabled (see RemoteCheck); only a
+			// branch tracking origin, or nothing, is pointed at origin/<def>.
+			remote := repo.GitConfig(fmt.Sprintf("branch.%s.remote", mainBranch))
+			if _, err := repo.Git("branch", "-m", mainBranch, def); err != nil {
+				fixed = append(fixed, r)
+				continue
+			}
+			if remote != "" && remote != "origin" {
+				msg += fmt.Sprintf(", renamed %s to %s, still tracking %s", mainBranch, def, remote)
+			} else {
+				if _, err := repo.Git("branch", "--set-upstream-to=origin/"+def, def); err != nil {
+					fixed = append(fixed, r)
+					continue
+				}
+				msg += fmt.Sprintf(", renamed %s to %s tracking origin/%s", mainBranch, def, def)
+			}
+		}
+		repo.resetMainBranch()
+		fixed = append(fixed, Result{
+			Name:    r.Name,
+			Status:  StatusFix,
+			Message: msg,
+		})
+	}
+	return fixed
+}
+
+// originDefaultBranch returns origin's default branch: from origin itself
+// when online is set, otherwise from the local origin/HEAD symref. Returns
+// "" when neither is known.
+func originDefaultBranch(repo *Repo) string {
+	if repo.Config.Online {
+		if out, err := repo.Git("ls-remote", "--symref", "origin", "HEAD"); err == nil {
+			if def := symrefHeadBranch(out); def != "" {
+				return def
+			}
+		}
+	}
+	ref, err := repo.Git("symbolic-ref", "--quiet", "--short", "refs/remotes/origin/HEAD")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(ref, "origin/")
+}
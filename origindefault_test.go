@@ -0,0 +1,75 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOriginDefaultCheck(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("a.txt", "a", "first", time.Now())
+	r.git("remote", "add", "origin", "https://github.com/me/repo.git")
+	r.reload()
+	if results := (&OriginDefaultCheck{}).Check(r.Repo); len(results) != 0 {
+		t.Errorf("without origin/HEAD: got %+v, want none", results)
+	}
+
+	r.git("update-ref", "refs/remotes/origin/main", "HEAD")
+	r.git("symbolic-ref", "refs/remotes/origin/HEAD", "refs/remotes/origin/main")
+	r.reload()
+	if got, _ := resultByName((&OriginDefaultCheck{}).Check(r.Repo), "remote/default-branch"); got.Status != StatusOK {
+		t.Errorf("matching default = %+v, want ok", got)
+	}
+
+	// origin renamed its default to trunk; the local clone still has main.
+	r.git("update-ref", "refs/remotes/origin/trunk", "HEAD")
+	r.git("symbolic-ref", "refs/remotes/origin/HEAD", "refs/remotes/origin/trunk")
+	r.reload()
+	results := (&OriginDefaultCheck{}).Check(r.Repo)
+	got, _ := resultByName(results, "remote/default-branch")
+	if got.Status != StatusWarn || !got.Fixable {
+		t.Fatalf("renamed default = %+v, want fixable warn", got)
+	}
+	if want := "origin's default branch is trunk, but the local main branch is main"; got.Message != want {
+		t.Errorf("message = %q, want %q", got.Message, want)
+	}
+
+	fixed := (&OriginDefaultCheck{}).Fix(r.Repo, results)
+	if got, _ := resultByName(fixed, "remote/default-branch"); got.Status != StatusFix {
+		t.Fatalf("after fix = %+v, want fix", got)
+	}
+	if v := r.git("rev-parse", "--abbrev-ref", "trunk@{upstream}"); v != "origin/trunk" {
+		t.Errorf("trunk@{upstream} = %q, want origin/trunk", v)
+	}
+	if got := r.Repo.MainBranch(); got != "trunk" {
+		t.Errorf("MainBranch() after fix = %q, want trunk", got)
+	}
+	if got, _ := resultByName((&OriginDefaultCheck{}).Check(r.Repo), "remote/default-branch"); got.Status != StatusOK {
+		t.Errorf("re-check = %+v, want ok", got)
+	}
+}
+
+func TestOriginDefaultFixKeepsForkTracking(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("a.txt", "a", "first", time.Now())
+	r.git("remote", "add", "origin", "https://github.com/me/repo.git")
+	r.git("remote", "add", "upstream", "https://github.com/owner/repo.git")
+	r.git("update-ref", "refs/remotes/upstream/main", "HEAD")
+	r.git("update-ref", "refs/remotes/origin/trunk", "HEAD")
+	r.git("symbolic-ref", "refs/remotes/origin/HEAD", "refs/remotes/origin/trunk")
+	r.git("branch", "--set-upstream-to=upstream/main", "main")
+	r.git("config", "branch.main.pushRemote", "DISABLED")
+	r.reload()
+
+	results := (&OriginDefaultCheck{}).Check(r.Repo)
+	fixed := (&OriginDefaultCheck{}).Fix(r.Repo, results)
+	if got, _ := resultByName(fixed, "remote/default-branch"); got.Status != StatusFix {
+		t.Fatalf("after fix = %+v, want fix", got)
+	}
+	if v := r.git("rev-parse", "--abbrev-ref", "trunk@{upstream}"); v != "upstream/main" {
+		t.Errorf("trunk@{upstream} = %q, want upstream/main", v)
+	}
+	if v := r.git("config", "branch.trunk.pushRemote"); v != "DISABLED" {
+		t.Errorf("branch.trunk.pushRemote = %q, want DISABLED", v)
+	}
+}
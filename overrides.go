@@ -0,0 +1,39 @@
+package main
+
+import "fmt"
+
+// overrideKeys are the git config keys whose local values commonly shadow
+// the global ones by accident, e.g. a user.email left over from a past
+// project.
+var overrideKeys = []string{"user.name", "user.email", "user.signingKey", "commit.gpgSign"}
+
+// ConfigOverrideCheck notes local git config values that differ from the
+// global ones. It is informational: the results are ok and show with
+// --verbose, explaining where an effective value comes from.
+type ConfigOverrideCheck struct{}
+
+func (c *ConfigOverrideCheck) Name() string { return "config-override" }
+
+func (c *ConfigOverrideCheck) Check(repo *Repo) []Result {
+	var results []Result
+	for _, key := range overrideKeys {
+		local := repo.GitConfig(key)
+		if local == "" {
+			continue
+		}
+		global, _ := repo.Git("config", "--global", "--get", key)
+		if global == "" || global == local {
+			continue
+		}
+		results = append(results, Result{
+			Name:    fmt.Sprintf("config/override[%s]", key),
+			Status:  StatusOK,
+			Message: fmt.Sprintf("local %q overrides global %q", local, global),
+		})
+	}
+	return results
+}
+
+func (c *ConfigOverrideCheck) Fix(_ *Repo, results []Result) []Result {
+	return results
+}
@@ -0,0 +1,18 @@
+package main
+
+import "testing"
+
+func TestConfigOverrideNotesShadowedGlobal(t *testing.T) {
+	r := newTestRepo(t)
+	r.git("config", "--global", "user.email", "global@example.com")
+	r.git("config", "--global", "user.name", "Test User")
+
+	results := (&ConfigOverrideCheck{}).Check(r.Repo)
+	got, ok := resultByName(results, "config/override[user.email]")
+	if !ok || got.Status != StatusOK {
+		t.Fatalf("config/override[user.email] = %+v, want ok note", results)
+	}
+	if _, ok := resultByName(results, "config/override[user.name]"); ok {
+		t.Error("identical local and global user.name should not be noted")
+	}
+}
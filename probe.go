@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 // Probe description and result types match the monitor's probe protocol.
@@ -123,18 +124,13 @@ func probeDescribe(cfg *Config) {
 			Required: map[string]probeArgSpec{
 				"Path": {
 					Type:        "string",
-					Description: "Root directory containing git repositories",
+					Description: "Root directory containing git repositories; separate several with commas to aggregate them",
 				},
 			},
 			Optional: optional,
 		},
 		Output: probeOutput{
-			Metrics: map[string]probeMetricSpec{
-				"repos_checked": {Type: "integer", Description: "Repositories scanned"},
-				"repos_ok":      {Type: "integer", Description: "Repositories with no issues"},
-				"repos_warned":  {Type: "integer", Description: "Repositories with warnings"},
-				"repos_failed":  {Type: "integer", Description: "Repositories with failures"},
-			},
+			Metrics: probeMetrics(cfg),
 		},
 		DefaultName:     "Git Lint: {{Path}}",
 		DefaultInterval: "1h",
@@ -143,6 +139,43 @@ func probeDescribe(cfg *Config) {
 	_ = json.NewEncoder(os.Stdout).Encode(desc)
 }
 
+// probeMetrics declares the repo counts plus, for each check the config
+// enables, the number of repos where it failed, so the monitor charts
+// exactly the checks probeRun reports.
+func probeMetrics(cfg *Config) map[string]probeMetricSpec {
+	metrics := map[string]probeMetricSpec{
+		"repos_checked": {Type: "integer", Description: "Repositories scanned"},
+		"repos_ok":      {Type: "integer", Description: "Repositories with no issues"},
+		"repos_warned":  {Type: "integer", Description: "Repositories with warnings"},
+		"repos_failed":  {Type: "integer", Description: "Repositories with failures"},
+	}
+	for _, c := range enabledChecks(allChecks(), cfg.Checks) {
+		metrics[checkFailedMetric(c.Name())] = probeMetricSpec{
+			Type:        "integer",
+			Description: fmt.Sprintf("Repositories where the %s check failed", c.Name()),
+		}
+	}
+	return metrics
+}
+
+// checkFailedMetric names the per-check failure metric, e.g.
+// repos_failed_fork_rename for the fork-rename check.
+func checkFailedMetric(check string) string {
+	return "repos_failed_" + strings.ReplaceAll(check, "-", "_")
+}
+
+// probeRoots splits the probe's Path argument into its comma-separated
+// roots, dropping empty entries.
+func probeRoots(path string) []string {
+	var roots []string
+	for _, root := range strings.Split(path, ",") {
+		if root = strings.TrimSpace(root); root != "" {
+			roots = append(roots, root)
+		}
+	}
+	return roots
+}
+
 func withDefault(spec probeArgSpec, value any) probeArgSpec {
 	spec.Default = value
 	return spec
@@ -159,93 +192,93 @@ func joinStrings(ss []string) string {
 	return result
 }
 
+// probeRun checks each repo under path, which may list several roots
+// separated by commas, and writes one probe result covering all of them. It
+// always returns exitOK: the monitor reads the status from the JSON result,
+// so errors and empty scans are reported there rather than as exit codes.
 func probeRun(path string, cfg *Config) int {
-	absPath, err := filepath.Abs(path)
-	if err != nil {
-		outputProbeResult(probeResult{
-			Status:  "critical",
-			Message: fmt.Sprintf("invalid path: %v", err),
-		})
-		return 0
-	}
-
-	if err := os.Chdir(absPath); err != nil {
+	roots := probeRoots(path)
+	if len(roots) == 0 {
 		outputProbeResult(probeResult{
 			Status:  "critical",
-			Message: fmt.Sprintf("cannot access %s: %v", path, err),
+			Message: fmt.Sprintf("invalid path %q", path),
 		})
-		return 0
+		return exitOK
 	}
 
 	opts := lintOptions{cfg: cfg}
 
-	entries, err := os.ReadDir(".")
-	if err != nil {
-		outputProbeResult(probeResult{
-			Status:  "critical",
-			Message: fmt.Sprintf("cannot read directory: %v", err),
-		})
-		return 0
-	}
-
-	var (
-		reposChecked int
-		reposOK      int
-		reposWarned  int
-		reposFailed  int
-		worstStatus  string = "ok"
-		message      string
-	)
-
-	for _, entry := range entries {
-		if !entry.IsDir() {
-			continue
-		}
-		if _, err := os.Stat(filepath.Join(entry.Name(), ".git")); err != nil {
-			continue
+	// Repos are named relative to their root, prefixed with the root when
+	// there are several, so sections from different roots stay apart.
+	type probeRepo struct{ name, dir string }
+	var repos []probeRepo
+	for _, root := range roots {
+		absRoot, err := filepath.Abs(root)
+		if err != nil {
+			outputProbeResult(probeResult{
+				Status:  "critical",
+				Message: fmt.Sprintf("invalid path %s: %v", root, err),
+			})
+			return exitOK
 		}
-
-		absDir, err := filepath.Abs(entry.Name())
+		names, err := findRepos(absRoot, 1, cfg.ExcludeDirs)
 		if err != nil {
-			continue
+			outputProbeResult(probeResult{
+				Status:  "critical",
+				Message: fmt.Sprintf("cannot access %s: %v", root, err),
+			})
+			return exitOK
 		}
+		for _, name := range names {
+			label := name
+			if len(roots) > 1 {
+				label = filepath.Join(root, name)
+			}
+			repos = append(repos, probeRepo{label, filepath.Join(absRoot, filepath.FromSlash(name))})
+		}
+	}
 
-		results, _ := runChecks(absDir, opts)
-		reposChecked++
-
-		repoStatus := classifyResults(results)
-		section := formatRepoSection(entry.Name(), results)
-
-		switch repoStatus {
-		case "critical":
-			reposFailed++
-			message += section
-			worstStatus = "critical"
-		case "warning":
-			reposWarned++
-			message += section
-			if worstStatus == "ok" {
-				worstStatus = "warning"
+	counts := newRepoSummary()
+	checkFailures := make(map[string]int)
+	var message string
+	for _, repo := range repos {
+		results, _ := runChecks(repo.dir, opts)
+		if counts.add(repo.name, results) != "ok" {
+			message += formatRepoSection(repo.name, results)
+		}
+		failed := make(map[string]bool)
+		for _, r := range results {
+			if r.Status == StatusFail && r.Check != "" && !failed[r.Check] {
+				failed[r.Check] = true
+				checkFailures[r.Check]++
 			}
-		default:
-			reposOK++
 		}
 	}
 
-	if reposChecked == 0 {
+	if counts.ReposChecked == 0 {
 		outputProbeResult(probeResult{
 			Status:  "ok",
 			Message: "no git repositories found",
 		})
-		return 0
+		return exitOK
+	}
+
+	metrics := map[string]any{
+		"repos_checked": counts.ReposChecked,
+		"repos_ok":      counts.ReposOK,
+		"repos_warned":  counts.ReposWarned,
+		"repos_failed":  counts.ReposFailed,
+	}
+	for _, c := range enabledChecks(allChecks(), cfg.Checks) {
+		metrics[checkFailedMetric(c.Name())] = checkFailures[c.Name()]
 	}
 
-	needAttention := reposWarned + reposFailed
+	needAttention := counts.ReposWarned + counts.ReposFailed
 	var summary string
 	if needAttention > 0 {
-		summary = fmt.Sprintf("%d of %d repos need attention", needAttention, reposChecked)
+		summary = fmt.Sprintf("%d of %d repos need attention", needAttention, counts.ReposChecked)
 	} else {
-		summary = fmt.Sprintf("%d repos clean", reposChecked)
+		summary = fmt.Sprintf("%d repos clean", counts.ReposChecked)
 	}
 
 	if message == "" {
@@ -253,33 +286,12 @@ func probeRun(path string, cfg *Config) int {
 	}
 
 	outputProbeResult(probeResult{
-		Status:  worstStatus,
+		Status:  counts.WorstStatus,
 		Summary: summary,
 		Message: message,
-		Metrics: map[string]any{
-			"repos_checked": reposChecked,
-			"repos_ok":      reposOK,
-			"repos_warned":  reposWarned,
-			"repos_failed":  reposFailed,
-		},
+		Metrics: metrics,
 	})
-	return 0
-}
-
-// classifyResults maps git-lint result statuses to probe statuses.
-// fail → critical, warn → warning, ok/fix → ok.
-// Returns the worst status across all results.
-func classifyResults(results []Result) string {
-	worst := "ok"
-	for _, r := range results {
-		switch r.Status {
-		case StatusFail:
-			return "critical"
-		case StatusWarn:
-			worst = "warning"
-		}
-	}
-	return worst
+	return exitOK
 }
 
 // formatRepoSection builds a Markdown section for a repo with issues.
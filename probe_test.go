@@ -1,28 +1,12 @@
 package main
 
 import (
+	"encoding/json"
+	"slices"
 	"strings"
 	"testing"
 )
 
-func TestClassifyResults(t *testing.T) {
-	tests := []struct {
-		name    string
-		results []Result
-		want    string
-	}{
-		{"empty", nil, "ok"},
-		{"all ok", []Result{{Status: StatusOK}}, "ok"},
-		{"warn", []Result{{Status: StatusOK}, {Status: StatusWarn}}, "warning"},
-		{"fail", []Result{{Status: StatusWarn}, {Status: StatusFail}}, "critical"},
-	}
-	for _, tt := range tests {
-		if got := classifyResults(tt.results); got != tt.want {
-			t.Errorf("classifyResults(%s) = %q, want %q", tt.name, got, tt.want)
-		}
-	}
-}
-
 func TestFormatRepoSection(t *testing.T) {
 	// ok and fix results produce no section.
 	if got := formatRepoSection("repo", []Result{{Name: "a", Status: StatusOK}, {Name: "b", Status: StatusFix}}); got != "" {
@@ -39,3 +23,39 @@ func TestFormatRepoSection(t *testing.T) {
 		}
 	}
 }
+
+func TestProbeMetrics(t *testing.T) {
+	metrics := probeMetrics(&Config{Checks: map[string]bool{"attribution": false}})
+	for _, name := range []string{"repos_checked", "repos_failed", "repos_failed_identity", "repos_failed_fork_rename"} {
+		if _, ok := metrics[name]; !ok {
+			t.Errorf("metric %s not declared", name)
+		}
+	}
+	// Disabled and opt-in checks report nothing, so they get no metric.
+	for _, name := range []string{"repos_failed_attribution", "repos_failed_large_files"} {
+		if _, ok := metrics[name]; ok {
+			t.Errorf("metric %s declared for a check that does not run", name)
+		}
+	}
+}
+
+func TestProbeRunMultipleRoots(t *testing.T) {
+	var roots []string
+	for _, name := range []string{"a", "b"} {
+		root := t.TempDir()
+		runGit(t, root, nil, "init", "-q", name)
+		roots = append(roots, root)
+	}
+
+	out := captureStdout(t, func() { probeRun(strings.Join(roots, ", "), &Config{}) })
+	var result probeResult
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("probe output %q: %v", out, err)
+	}
+	if got := result.Metrics["repos_checked"]; got != float64(2) {
+		t.Errorf("repos_checked = %v, want 2 across both roots", got)
+	}
+	if !slices.Equal(probeRoots(" a,,b "), []string{"a", "b"}) {
+		t.Errorf("probeRoots(\" a,,b \") = %q, want [a b]", probeRoots(" a,,b "))
+	}
+}
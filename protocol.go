@@ -2,11 +2,15 @@ package main
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 )
 
 type ProtocolCheck struct{}
 
+func (c *ProtocolCheck) Name() string { return "protocol" }
+
 func (c *ProtocolCheck) Check(repo *Repo) []Result {
 	want := repo.Config.Protocol
 	if want == "" {
@@ -20,19 +24,32 @@ func (c *ProtocolCheck) Check(repo *Repo) []Result {
 
 	var results []Result
 	for _, name := range remotes {
+		if protocolIgnored(repo, name) {
+			continue
+		}
 		url := repo.RemoteURL(name)
-		if owner, _ := parseGitHubRepo(url); owner == "" {
+		if !protocolHost(repo.Config, urlHost(url)) {
 			continue
 		}
 		got := urlProtocol(url)
 		if got == want {
 			continue
 		}
+		// Only http(s) and ssh remotes are converted; git:// and other
+		// transports are reported for the user to change.
+		if got != "http" && got != "https" && got != "ssh" {
+			results = append(results, Result{
+				Name:    fmt.Sprintf("remote/protocol[%s]", name),
+				Status:  StatusWarn,
+				Message: fmt.Sprintf("uses %s, want %s (%s); not converted automatically", protocolLabel(got), want, url),
+			})
+			continue
+		}
 		results = append(results, Result{
 			Name:    fmt.Sprintf("remote/protocol[%s]", name),
 			Status:  StatusFail,
 			Message: fmt.Sprintf("uses %s, want %s (%s)", got, want, url),
-			Fixable: true,
+			Fixable: convertRemoteURL(url, want) != "",
 		})
 	}
 
@@ -40,7 +57,7 @@ func (c *ProtocolCheck) Check(repo *Repo) []Result {
 		return []Result{{
 			Name:    "remote/protocol",
 			Status:  StatusOK,
-			Message: fmt.Sprintf("all GitHub remotes use %s", want),
+			Message: fmt.Sprintf("all remotes on %s use %s", strings.Join(protocolHosts(repo.Config), ", "), want),
 		}}
 	}
 	return results
@@ -57,7 +74,7 @@ func (c *ProtocolCheck) Fix(repo *Repo, results []Result) []Result {
 		// Extract remote name from "remote/protocol[name]".
 		name := r.Name[len("remote/protocol[") : len(r.Name)-1]
 		url := repo.RemoteURL(name)
-		converted := convertGitHubURL(url, want)
+		converted := convertRemoteURL(url, want)
 		if converted == "" {
 			fixed = append(fixed, r)
 			continue
@@ -76,32 +93,170 @@ func (c *ProtocolCheck) Fix(repo *Repo, results []Result) []Result {
 	return fixed
 }
 
-// convertGitHubURL converts a GitHub URL between ssh and https.
-// Returns "" if the URL is not a GitHub URL or already uses the target protocol.
-func convertGitHubURL(url, target string) string {
+// PushProtocolCheck enforces org-specific push protocols for work repos,
+// e.g. ssh for orgs whose pushes go through deploy keys. pushProtocols maps a
+// GitHub org to the protocol origin must push with; the fix sets origin's
+// push URL only, leaving the fetch URL to ProtocolCheck.
+type PushProtocolCheck struct{}
+
+func (c *PushProtocolCheck) Name() string { return "push-protocol" }
+
+func (c *PushProtocolCheck) Check(repo *Repo) []Result {
+	if !repo.Work || len(repo.Config.PushProtocols) == 0 {
+		return nil
+	}
+	url, err := repo.Git("remote", "get-url", "--push", "origin")
+	if err != nil {
+		return nil
+	}
+	owner, _ := parseGitHubRepo(url)
+	want := pushProtocolFor(repo.Config.PushProtocols, owner)
+	if owner == "" || want == "" {
+		return nil
+	}
+	if got := urlProtocol(url); got != want {
+		return []Result{{
+			Name:    "remote/push-protocol",
+			Status:  StatusFail,
+			Message: fmt.Sprintf("origin pushes with %s, org %s requires %s (%s)", got, owner, want, url),
+			Fixable: true,
+		}}
+	}
+	return []Result{{
+		Name:    "remote/push-protocol",
+		Status:  StatusOK,
+		Message: fmt.Sprintf("origin pushes with %s as org %s requires", want, owner),
+	}}
+}
+
+func (c *PushProtocolCheck) Fix(repo *Repo, results []Result) []Result {
+	var fixed []Result
+	for _, r := range results {
+		if r.Status != StatusFail || !r.Fixable {
+			fixed = append(fixed, r)
+			continue
+		}
+		url, _ := repo.Git("remote", "get-url", "--push", "origin")
+		owner, _ := parseGitHubRepo(url)
+		converted := convertRemoteURL(url, pushProtocolFor(repo.Config.PushProtocols, owner))
+		if converted == "" {
+			fixed = append(fixed, r)
+			continue
+		}
+		if _, err := repo.Git("remote", "set-url", "--push", "origin", converted); err != nil {
+			fixed = append(fixed, r)
+			continue
+		}
+		fixed = append(fixed, Result{
+			Name:    r.Name,
+			Status:  StatusFix,
+			Message: fmt.Sprintf("set origin push URL to %s", converted),
+		})
+	}
+	return fixed
+}
+
+// pushProtocolFor returns the protocol required for owner. Keys may be globs
+// like workOrgs entries; an exact key wins, then the first matching glob in
+// sorted order.
+func pushProtocolFor(protocols map[string]string, owner string) string {
+	if p, ok := protocols[owner]; ok {
+		return p
+	}
+	for _, pattern := range slices.Sorted(maps.Keys(protocols)) {
+		if matchOrg(pattern, owner) {
+			return protocols[pattern]
+		}
+	}
+	return ""
+}
+
+// defaultProtocolHosts are the hosts ProtocolCheck covers when the
+// protocolHosts config is empty.
+var defaultProtocolHosts = []string{"github.com", "gitlab.com"}
+
+// protocolHosts returns the hosts whose remotes ProtocolCheck converts.
+func protocolHosts(cfg *Config) []string {
+	if len(cfg.ProtocolHosts) > 0 {
+		return cfg.ProtocolHosts
+	}
+	return defaultProtocolHosts
+}
+
+// protocolHost reports whether ProtocolCheck covers remotes on host.
+func protocolHost(cfg *Config, host string) bool {
+	if host == "" {
+		return false
+	}
+	return slices.ContainsFunc(protocolHosts(cfg), func(h string) bool {
+		return strings.EqualFold(h, host)
+	})
+}
+
+// protocolIgnoreKey is the per-remote git config flag that keeps
+// ProtocolCheck away from a remote, e.g. a read-only mirror deliberately left
+// on https.
+const protocolIgnoreKey = "gitLintIgnoreProtocol"
+
+// protocolIgnored reports whether ProtocolCheck skips the remote name, because
+// protocolIgnoreRemotes lists it or remote.<name>.gitLintIgnoreProtocol is
+// true.
+func protocolIgnored(repo *Repo, name string) bool {
+	if slices.Contains(repo.Config.ProtocolIgnoreRemotes, name) {
+		return true
+	}
+	ignored, _ := repo.Git("config", "--type=bool", "--get", fmt.Sprintf("remote.%s.%s", name, protocolIgnoreKey))
+	return ignored == "true"
+}
+
+// convertRemoteURL converts a remote URL on any host between ssh and
+// http(s); http URLs become https either way. Returns "" if the URL is in
+// none of these forms or already uses the target protocol.
+func convertRemoteURL(url, target string) string {
+	host, path := parseRemoteURL(url)
+	if host == "" {
+		return ""
+	}
 	switch target {
 	case "ssh":
-		// https://github.com/org/repo.git → git@github.com:org/repo.git
-		if path, ok := strings.CutPrefix(url, "https://github.com/"); ok {
-			return "git@github.com:" + path
+		// https://host/org/repo.git → git@host:org/repo.git
+		if strings.HasPrefix(url, "https://") || strings.HasPrefix(url, "http://") {
+			return "git@" + host + ":" + path
 		}
 	case "https":
-		// git@github.com:org/repo.git → https://github.com/org/repo.git
-		if path, ok := strings.CutPrefix(url, "git@github.com:"); ok {
-			return "https://github.com/" + path
+		// git@host:org/repo.git → https://host/org/repo.git
+		if strings.HasPrefix(url, "git@") || strings.HasPrefix(url, "http://") {
+			return "https://" + host + "/" + path
 		}
 	}
 	return ""
 }
 
-// urlProtocol returns "ssh" or "https" based on the remote URL format.
+// urlProtocol returns the transport of a remote URL from its scheme:
+// "https", "http", "ssh", "git", or "file". SCP-like URLs
+// ([user@]host:path) are ssh. Returns "" for local paths and unknown
+// schemes.
 func urlProtocol(url string) string {
-	if strings.HasPrefix(url, "https://") {
-		return "https"
+	if scheme, _, ok := strings.Cut(url, "://"); ok {
+		switch scheme {
+		case "https", "http", "ssh", "git", "file":
+			return scheme
+		case "git+ssh", "ssh+git":
+			return "ssh"
+		}
+		return ""
 	}
-	// SCP-like syntax (git@host:path) or explicit ssh:// URLs.
-	if strings.HasPrefix(url, "ssh://") || strings.Contains(url, "@") {
+	// Like git, treat a colon before any slash as SCP-like syntax.
+	if i := strings.IndexByte(url, ':'); i > 0 && !strings.Contains(url[:i], "/") {
 		return "ssh"
 	}
 	return ""
 }
+
+// protocolLabel names a urlProtocol result in messages.
+func protocolLabel(protocol string) string {
+	if protocol == "" {
+		return "an unknown protocol"
+	}
+	return protocol
+}
@@ -10,7 +10,13 @@ func TestURLProtocol(t *testing.T) {
 		{"https://github.com/owner/repo.git", "https"},
 		{"git@github.com:owner/repo.git", "ssh"},
 		{"ssh://git@github.com/owner/repo.git", "ssh"},
+		{"https://user@github.com/owner/repo.git", "https"},
+		{"http://git.example.com/repo.git", "http"},
+		{"git://github.com/owner/repo.git", "git"},
+		{"file:///srv/git/repo.git", "file"},
+		{"github.com:owner/repo.git", "ssh"},
 		{"/local/path/repo", ""},
+		{"./with:colon/repo", ""},
 	}
 	for _, tt := range tests {
 		if got := urlProtocol(tt.url); got != tt.want {
@@ -41,6 +47,56 @@ func TestProtocolCheckConvertsRemote(t *testing.T) {
 	}
 }
 
+func TestProtocolCheckHosts(t *testing.T) {
+	r := newTestRepo(t)
+	r.git("remote", "add", "origin", "https://gitlab.com/owner/repo.git")
+	r.git("remote", "add", "internal", "https://git.corp.example/owner/repo.git")
+	r.Config.Protocol = "ssh"
+	r.reload()
+
+	results := (&ProtocolCheck{}).Check(r.Repo)
+	if got, _ := resultByName(results, "remote/protocol[origin]"); got.Status != StatusFail || !got.Fixable {
+		t.Errorf("gitlab.com remote = %+v, want fixable fail", got)
+	}
+	if _, ok := resultByName(results, "remote/protocol[internal]"); ok {
+		t.Error("remote on an unlisted host was checked")
+	}
+
+	r.Config.ProtocolHosts = []string{"git.corp.example"}
+	results = (&ProtocolCheck{}).Check(r.Repo)
+	if _, ok := resultByName(results, "remote/protocol[origin]"); ok {
+		t.Error("gitlab.com still checked after protocolHosts replaced the defaults")
+	}
+	(&ProtocolCheck{}).Fix(r.Repo, results)
+	if url := r.git("remote", "get-url", "internal"); url != "git@git.corp.example:owner/repo.git" {
+		t.Errorf("internal url = %q, want ssh form", url)
+	}
+}
+
+func TestProtocolCheckIgnoredRemotes(t *testing.T) {
+	r := newTestRepo(t)
+	r.git("remote", "add", "origin", "https://github.com/owner/repo.git")
+	r.git("remote", "add", "mirror", "https://gitlab.com/owner/repo.git")
+	r.Config.Protocol = "ssh"
+	r.reload()
+
+	r.Config.ProtocolIgnoreRemotes = []string{"origin"}
+	r.git("config", "remote.mirror.gitLintIgnoreProtocol", "true")
+	results := (&ProtocolCheck{}).Check(r.Repo)
+	if got, ok := resultByName(results, "remote/protocol"); !ok || got.Status != StatusOK || len(results) != 1 {
+		t.Errorf("all remotes ignored: got %+v, want a single ok", results)
+	}
+
+	r.Config.ProtocolIgnoreRemotes = nil
+	results = (&ProtocolCheck{}).Check(r.Repo)
+	if got, _ := resultByName(results, "remote/protocol[origin]"); got.Status != StatusFail {
+		t.Errorf("origin no longer ignored = %+v, want fail", got)
+	}
+	if _, ok := resultByName(results, "remote/protocol[mirror]"); ok {
+		t.Error("mirror checked despite remote.mirror.gitLintIgnoreProtocol")
+	}
+}
+
 func TestProtocolCheckDisabledWhenUnset(t *testing.T) {
 	r := newTestRepo(t)
 	r.git("remote", "add", "origin", "https://github.com/owner/repo.git")
@@ -51,7 +107,7 @@ func TestProtocolCheckDisabledWhenUnset(t *testing.T) {
 	}
 }
 
-func TestConvertGitHubURL(t *testing.T) {
+func TestConvertRemoteURL(t *testing.T) {
 	tests := []struct {
 		url    string
 		target string
@@ -61,11 +117,72 @@ func TestConvertGitHubURL(t *testing.T) {
 		{"git@github.com:owner/repo.git", "https", "https://github.com/owner/repo.git"},
 		{"git@github.com:owner/repo.git", "ssh", ""},
 		{"https://github.com/owner/repo.git", "https", ""},
-		{"https://gitlab.com/owner/repo.git", "ssh", ""},
+		{"https://gitlab.com/group/sub/repo.git", "ssh", "git@gitlab.com:group/sub/repo.git"},
+		{"git@bitbucket.org:owner/repo.git", "https", "https://bitbucket.org/owner/repo.git"},
+		{"http://github.com/owner/repo.git", "ssh", "git@github.com:owner/repo.git"},
+		{"http://github.com/owner/repo.git", "https", "https://github.com/owner/repo.git"},
+		{"git://github.com/owner/repo.git", "ssh", ""},
+		{"/local/path/repo", "ssh", ""},
 	}
 	for _, tt := range tests {
-		if got := convertGitHubURL(tt.url, tt.target); got != tt.want {
-			t.Errorf("convertGitHubURL(%q, %q) = %q, want %q", tt.url, tt.target, got, tt.want)
+		if got := convertRemoteURL(tt.url, tt.target); got != tt.want {
+			t.Errorf("convertRemoteURL(%q, %q) = %q, want %q", tt.url, tt.target, got, tt.want)
 		}
 	}
 }
+
+func TestPushProtocolCheckPerOrg(t *testing.T) {
+	r := newTestRepo(t)
+	r.git("remote", "add", "origin", "https://github.com/acme/repo.git")
+	r.Config.WorkOrgs = []string{"acme"}
+	r.Config.PushProtocols = map[string]string{"acme": "ssh"}
+	r.reload()
+
+	results := (&PushProtocolCheck{}).Check(r.Repo)
+	got, ok := resultByName(results, "remote/push-protocol")
+	if !ok || got.Status != StatusFail || !got.Fixable {
+		t.Fatalf("push-protocol = %+v, want fixable fail", results)
+	}
+
+	(&PushProtocolCheck{}).Fix(r.Repo, results)
+	if url := r.git("remote", "get-url", "--push", "origin"); url != "git@github.com:acme/repo.git" {
+		t.Errorf("push URL after fix = %q, want ssh", url)
+	}
+	if url := r.git("remote", "get-url", "origin"); url != "https://github.com/acme/repo.git" {
+		t.Errorf("fetch URL after fix = %q, want unchanged", url)
+	}
+	if got, _ := resultByName((&PushProtocolCheck{}).Check(r.Repo), "remote/push-protocol"); got.Status != StatusOK {
+		t.Errorf("after fix = %+v, want ok", got)
+	}
+}
+
+func TestPushProtocolFor(t *testing.T) {
+	protocols := map[string]string{"acme-*": "ssh", "acme-web": "https"}
+	for owner, want := range map[string]string{"acme-web": "https", "acme-infra": "ssh", "other": ""} {
+		if got := pushProtocolFor(protocols, owner); got != want {
+			t.Errorf("pushProtocolFor(%q) = %q, want %q", owner, got, want)
+		}
+	}
+}
+
+func TestProtocolCheckOtherTransports(t *testing.T) {
+	r := newTestRepo(t)
+	r.git("remote", "add", "origin", "https://user@github.com/owner/repo.git")
+	r.git("remote", "add", "mirror", "git://github.com/owner/repo.git")
+	r.Config.Protocol = "https"
+	r.reload()
+
+	results := (&ProtocolCheck{}).Check(r.Repo)
+	if _, ok := resultByName(results, "remote/protocol[origin]"); ok {
+		t.Errorf("authenticated https remote reported: %+v", results)
+	}
+	got, ok := resultByName(results, "remote/protocol[mirror]")
+	if !ok || got.Status != StatusWarn || got.Fixable {
+		t.Fatalf("git:// remote = %+v, want non-fixable warn", got)
+	}
+
+	(&ProtocolCheck{}).Fix(r.Repo, results)
+	if url := r.git("remote", "get-url", "mirror"); url != "git://github.com/owner/repo.git" {
+		t.Errorf("mirror url = %q, want it unchanged", url)
+	}
+}
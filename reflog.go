@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// ReflogCheck warns when the reflog of HEAD or the main branch has grown
+// past thresholds.reflogMaxEntries. Tens of thousands of entries usually
+// mean a script keeps resetting or checking out in the repo. Advisory only:
+// the cause needs investigating before git reflog expire cleans up.
+type ReflogCheck struct{}
+
+func (c *ReflogCheck) Name() string { return "reflog" }
+
+func (c *ReflogCheck) Check(repo *Repo) []Result {
+	maxEntries := repo.Config.Thresholds.ReflogMaxEntries
+	if maxEntries == 0 {
+		return nil
+	}
+
+	refs := []string{"HEAD"}
+	if mainBranch := repo.MainBranch(); mainBranch != "" {
+		refs = append(refs, mainBranch)
+	}
+	var details []string
+	for _, ref := range refs {
+		if n := reflogEntries(repo, ref); n > maxEntries {
+			details = append(details, fmt.Sprintf("%s: %d entries", ref, n))
+		}
+	}
+
+	if len(details) > 0 {
+		return []Result{{
+			Name:    "state/reflog",
+			Status:  StatusWarn,
+			Message: fmt.Sprintf("reflog has more than %d entries; check for runaway automation, then git reflog expire", maxEntries),
+			Details: details,
+		}}
+	}
+	return []Result{{
+		Name:    "state/reflog",
+		Status:  StatusOK,
+		Message: fmt.Sprintf("reflogs within %d entries", maxEntries),
+	}}
+}
+
+func (c *ReflogCheck) Fix(_ *Repo, results []Result) []Result {
+	return results
+}
+
+// reflogEntries returns the number of reflog entries for ref, or 0 when it
+// has no reflog.
+func reflogEntries(repo *Repo, ref string) int {
+	out, err := repo.Git("rev-list", "--walk-reflogs", "--count", ref)
+	if err != nil {
+		return 0
+	}
+	n, _ := strconv.Atoi(out)
+	return n
+}
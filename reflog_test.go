@@ -0,0 +1,31 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReflogCheck(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("a.txt", "a", "first", time.Now())
+	for range 3 {
+		r.git("checkout", "-q", "-b", "tmp")
+		r.git("checkout", "-q", "main")
+		r.git("branch", "-D", "tmp")
+	}
+
+	if results := (&ReflogCheck{}).Check(r.Repo); len(results) != 0 {
+		t.Errorf("threshold unset: got %+v, want none", results)
+	}
+
+	r.Config.Thresholds.ReflogMaxEntries = 5
+	got, _ := resultByName((&ReflogCheck{}).Check(r.Repo), "state/reflog")
+	if got.Status != StatusWarn || len(got.Details) != 1 {
+		t.Errorf("HEAD with 7 entries = %+v, want warn listing HEAD only", got)
+	}
+
+	r.Config.Thresholds.ReflogMaxEntries = 100
+	if got, _ := resultByName((&ReflogCheck{}).Check(r.Repo), "state/reflog"); got.Status != StatusOK {
+		t.Errorf("within threshold = %+v, want ok", got)
+	}
+}
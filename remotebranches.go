@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RemoteBranchesCheck warns when origin holds more of the user's own
+// branches than thresholds.remoteBranchesMax, listing them so the fork can
+// be tidied on the server. It queries origin with git ls-remote, so it only
+// runs when both the online config option and the threshold are set.
+type RemoteBranchesCheck struct{}
+
+func (c *RemoteBranchesCheck) Name() string { return "remote-branches" }
+
+func (c *RemoteBranchesCheck) Check(repo *Repo) []Result {
+	maxBranches := repo.Config.Thresholds.RemoteBranchesMax
+	if !repo.Config.Online || maxBranches == 0 {
+		return nil
+	}
+	remotes, _ := repo.Remotes()
+	if !hasRemote(remotes, "origin") {
+		return nil
+	}
+	out, err := repo.Git("ls-remote", "--heads", "origin")
+	if err != nil {
+		return nil
+	}
+
+	mainBranch := repo.MainBranch()
+	now := time.Now()
+	var details []string
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		sha, branch := fields[0], strings.TrimPrefix(fields[1], "refs/heads/")
+		if branch == mainBranch || branch == "reviews" {
+			continue
+		}
+		// Authorship comes from local objects; branches never fetched are
+		// skipped rather than fetched.
+		meta, err := repo.Git("log", "-1", "--format=%an|%ct", sha)
+		if err != nil {
+			continue
+		}
+		author, stamp, _ := strings.Cut(meta, "|")
+		if author != repo.Config.Identity.Name {
+			continue
+		}
+		detail := branch
+		if unix, err := strconv.ParseInt(stamp, 10, 64); err == nil {
+			detail += fmt.Sprintf(" (%s ago)", formatDuration(now.Sub(time.Unix(unix, 0))))
+		}
+		details = append(details, detail)
+	}
+
+	if len(details) > maxBranches {
+		return []Result{{
+			Name:    "remote/branches",
+			Status:  StatusWarn,
+			Message: fmt.Sprintf("%d of your branches on origin (max %d)", len(details), maxBranches),
+			Details: details,
+		}}
+	}
+	return []Result{{
+		Name:    "remote/branches",
+		Status:  StatusOK,
+		Message: fmt.Sprintf("%d of your branches on origin", len(details)),
+	}}
+}
+
+func (c *RemoteBranchesCheck) Fix(_ *Repo, results []Result) []Result {
+	return results
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRemoteBranchesCheck(t *testing.T) {
+	// A bare repo stands in for the server, so ls-remote stays local.
+	server := t.TempDir()
+	runGit(t, server, nil, "init", "--bare", "--initial-branch=main")
+
+	r := newTestRepo(t)
+	r.commit("a.txt", "a", "first", time.Now())
+	r.git("remote", "add", "origin", server)
+	r.git("push", "origin", "main")
+	for _, b := range []string{"feature-a", "feature-b"} {
+		r.git("checkout", "-b", b, "main")
+		r.commit(b+".txt", b, b, time.Now())
+		r.git("push", "origin", b)
+	}
+	r.git("checkout", "-b", "theirs", "main")
+	r.commitAs("t.txt", "t", "their work", "Other Dev", "other@example.com", time.Now())
+	r.git("push", "origin", "theirs")
+	r.git("checkout", "main")
+	r.reload()
+
+	if results := (&RemoteBranchesCheck{}).Check(r.Repo); len(results) != 0 {
+		t.Errorf("offline: got %+v, want none", results)
+	}
+
+	r.Config.Online = true
+	r.Config.Thresholds.RemoteBranchesMax = 1
+	got, ok := resultByName((&RemoteBranchesCheck{}).Check(r.Repo), "remote/branches")
+	if !ok || got.Status != StatusWarn || len(got.Details) != 2 {
+		t.Fatalf("remote/branches = %+v, want warn listing 2 own branches", got)
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// RemoteHostCheck warns about remotes that point at a literal IP address.
+// Such URLs usually come from cloning off a temporary machine and break as
+// soon as the address changes.
+type RemoteHostCheck struct{}
+
+func (c *RemoteHostCheck) Name() string { return "remote-host" }
+
+func (c *RemoteHostCheck) Check(repo *Repo) []Result {
+	remotes, _ := repo.Remotes()
+	if len(remotes) == 0 {
+		return nil
+	}
+
+	var results []Result
+	for _, name := range remotes {
+		host := urlHost(repo.RemoteURL(name))
+		if net.ParseIP(host) == nil {
+			continue
+		}
+		results = append(results, Result{
+			Name:    fmt.Sprintf("remote/host[%s]", name),
+			Status:  StatusWarn,
+			Message: fmt.Sprintf("host is IP address %s; use a DNS name", host),
+		})
+	}
+
+	if len(results) == 0 {
+		return []Result{{
+			Name:    "remote/host",
+			Status:  StatusOK,
+			Message: "all remotes use hostnames",
+		}}
+	}
+	return results
+}
+
+func (c *RemoteHostCheck) Fix(_ *Repo, results []Result) []Result {
+	return results
+}
+
+// urlHost returns the host part of a git remote URL, without port, user, or
+// IPv6 brackets. It handles scheme URLs (https://, ssh://) and SCP-like
+// syntax (user@host:path). Returns "" for local paths.
+func urlHost(rawURL string) string {
+	if strings.Contains(rawURL, "://") {
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			return ""
+		}
+		return u.Hostname()
+	}
+	// SCP-like syntax: [user@]host:path, with IPv6 hosts in brackets.
+	rest := rawURL
+	if i := strings.IndexByte(rest, '@'); i >= 0 {
+		rest = rest[i+1:]
+	}
+	if strings.HasPrefix(rest, "[") {
+		if end := strings.IndexByte(rest, ']'); end > 0 {
+			return rest[1:end]
+		}
+		return ""
+	}
+	i := strings.IndexByte(rest, ':')
+	if i <= 0 || strings.Contains(rest[:i], "/") {
+		return ""
+	}
+	return rest[:i]
+}
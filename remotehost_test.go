@@ -0,0 +1,37 @@
+package main
+
+import "testing"
+
+func TestURLHost(t *testing.T) {
+	tests := []struct {
+		url, want string
+	}{
+		{"git@github.com:org/repo.git", "github.com"},
+		{"https://github.com/org/repo.git", "github.com"},
+		{"ssh://git@10.0.0.5:2222/repo.git", "10.0.0.5"},
+		{"git@10.0.0.5:repo.git", "10.0.0.5"},
+		{"git@[fe80::1]:repo.git", "fe80::1"},
+		{"https://[2001:db8::1]/repo.git", "2001:db8::1"},
+		{"/srv/git/repo.git", ""},
+		{"../repo", ""},
+	}
+	for _, tt := range tests {
+		if got := urlHost(tt.url); got != tt.want {
+			t.Errorf("urlHost(%q) = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestRemoteHostIPAddress(t *testing.T) {
+	r := newTestRepo(t)
+	r.git("remote", "add", "origin", "git@github.com:me/repo.git")
+	r.git("remote", "add", "scratch", "git@10.0.0.5:repo.git")
+
+	results := (&RemoteHostCheck{}).Check(r.Repo)
+	if got, ok := resultByName(results, "remote/host[scratch]"); !ok || got.Status != StatusWarn {
+		t.Errorf("remote/host[scratch] = %+v, want warn", results)
+	}
+	if _, ok := resultByName(results, "remote/host[origin]"); ok {
+		t.Error("hostname remote should not be flagged")
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
 )
 
@@ -11,6 +12,8 @@ import (
 // on the correct remote layout.
 type ForkSetupCheck struct{}
 
+func (c *ForkSetupCheck) Name() string { return "fork-setup" }
+
 func (c *ForkSetupCheck) Check(repo *Repo) []Result {
 	remotes, _ := repo.Remotes()
 	if hasRemote(remotes, "upstream") {
@@ -31,7 +34,7 @@ func (c *ForkSetupCheck) Check(repo *Repo) []Result {
 		return nil
 	}
 
-	if !ghHasFork(me, owner, repoName) {
+	if !ghHasFork(repo.Context(), me, owner, repoName) {
 		return nil
 	}
 
@@ -79,8 +82,10 @@ func (c *ForkSetupCheck) Fix(repo *Repo, results []Result) []Result {
 		}
 
 		// The rename moves remote.origin.* config to remote.upstream.*.
-		// Clear the stale fork-parent cache from the renamed remote.
-		repo.UnsetGitConfig("remote.upstream.gh-parent")
+		// Clear the stale GitHub lookup caches from the renamed remote.
+		for _, key := range []string{"gh-parent", "gh-parent-checked", "gh-archived"} {
+			repo.UnsetGitConfig("remote.upstream." + key)
+		}
 
 		forkURL := githubCloneURL(me, repoName, protocol)
 		if _, err := repo.Git("remote", "add", "origin", forkURL); err != nil {
@@ -100,6 +105,8 @@ func (c *ForkSetupCheck) Fix(repo *Repo, results []Result) []Result {
 
 type RemoteCheck struct{}
 
+func (c *RemoteCheck) Name() string { return "remotes" }
+
 func (c *RemoteCheck) Check(repo *Repo) []Result {
 	remotes, _ := repo.Remotes()
 	if len(remotes) < 2 {
@@ -246,9 +253,57 @@ func (c *RemoteCheck) Check(repo *Repo) []Result {
 		})
 	}
 
+	if hasUpstream {
+		results = append(results, forkWorkflowRollup(results, parentRemote))
+	}
+
 	return results
 }
 
+// forkWorkflowParts are the results that together make up the work fork
+// workflow: origin is the fork, upstream its parent, and main tracks
+// upstream without being able to push there.
+var forkWorkflowParts = []string{"remote/origin", "remote/gh-resolved", "remote/tracking", "remote/push-guard"}
+
+// forkWorkflowRollup summarizes forkWorkflowParts in results as a single
+// remote/fork-workflow result, itemizing the parts that aren't right. The
+// parts keep their own results for fixing; fixed parts count as correct.
+func forkWorkflowRollup(results []Result, parentRemote string) Result {
+	var details []string
+	status := StatusOK
+	worsen := func(s string) {
+		if s == StatusFail || (s == StatusWarn && status == StatusOK) {
+			status = s
+		}
+	}
+	if parentRemote != "" && parentRemote != "upstream" {
+		details = append(details, fmt.Sprintf("upstream: fork parent is remote %s, not upstream", parentRemote))
+		worsen(StatusFail)
+	}
+	for _, name := range forkWorkflowParts {
+		for _, r := range results {
+			if r.Name != name || r.Status == StatusOK || r.Status == StatusFix {
+				continue
+			}
+			details = append(details, fmt.Sprintf("%s: %s", name, r.Message))
+			worsen(r.Status)
+		}
+	}
+	if status == StatusOK {
+		return Result{
+			Name:    "remote/fork-workflow",
+			Status:  StatusOK,
+			Message: "fork workflow configured",
+		}
+	}
+	return Result{
+		Name:    "remote/fork-workflow",
+		Status:  status,
+		Message: fmt.Sprintf("%d parts of the fork workflow are misconfigured", len(details)),
+		Details: details,
+	}
+}
+
 // upstreamTrackingResults checks that a branch tracks upstream and disables
 // pushes, the configuration the default and release-* branches share.
 func upstreamTrackingResults(repo *Repo, branch, trackName, guardName string) []Result {
@@ -313,6 +368,16 @@ func fixPushGuard(repo *Repo, branch string) error {
 	return repo.SetGitConfig(fmt.Sprintf("branch.%s.pushRemote", branch), "DISABLED")
 }
 
+// guardForkBranch applies both fixes to branch: it tracks upstream and
+// cannot push. cloneRepo uses it for any fork; RemoteCheck.Fix applies the
+// halves separately, as each is its own result.
+func guardForkBranch(repo *Repo, branch string) error {
+	if err := fixUpstreamTracking(repo, branch); err != nil {
+		return err
+	}
+	return fixPushGuard(repo, branch)
+}
+
 func (c *RemoteCheck) Fix(repo *Repo, results []Result) []Result {
 	var fixed []Result
 	mainBranch := repo.MainBranch()
@@ -434,6 +499,13 @@ func (c *RemoteCheck) Fix(repo *Repo, results []Result) []Result {
 			fixed = append(fixed, r)
 		}
 	}
+
+	// Re-summarize the roll-up now that some parts may be fixed.
+	for i, r := range fixed {
+		if r.Name == "remote/fork-workflow" {
+			fixed[i] = forkWorkflowRollup(fixed, repo.ForkParentRemote())
+		}
+	}
 	return fixed
 }
 
@@ -447,17 +519,43 @@ func hasRemote(remotes []string, name string) bool {
 	return false
 }
 
-// workOrgInURL returns the work org name found in the URL, or "".
+// workOrgInURL returns the workOrgs entry matching the owner of a GitHub
+// URL, or "". Entries may be globs such as "acme-*".
 func workOrgInURL(url string, orgs []string) string {
+	owner := githubURLOwner(url)
+	if owner == "" {
+		return ""
+	}
 	for _, org := range orgs {
-		if strings.Contains(url, "github.com/"+org+"/") ||
-			strings.Contains(url, "github.com:"+org+"/") {
+		if matchOrg(org, owner) {
 			return org
 		}
 	}
 	return ""
 }
 
+// matchOrg reports whether a GitHub owner matches an org pattern: an exact
+// name or a filepath.Match glob, compared case-insensitively as GitHub does.
+func matchOrg(pattern, owner string) bool {
+	ok, err := filepath.Match(strings.ToLower(pattern), strings.ToLower(owner))
+	return err == nil && ok
+}
+
+// githubURLOwner returns the owner in an https, scp-like, or ssh:// GitHub
+// URL, or "" for anything else.
+func githubURLOwner(url string) string {
+	for _, prefix := range []string{"https://github.com/", "http://github.com/", "git@github.com:", "ssh://git@github.com/"} {
+		if rest, ok := strings.CutPrefix(url, prefix); ok {
+			owner, _, found := strings.Cut(rest, "/")
+			if !found {
+				return ""
+			}
+			return owner
+		}
+	}
+	return ""
+}
+
 // branchExists reports whether name appears in the for-each-ref output.
 func branchExists(branchOut, name string) bool {
 	for _, b := range strings.Split(branchOut, "\n") {
@@ -473,7 +571,7 @@ func branchExists(branchOut, name string) bool {
 func reviewsExpectedRemote(repo *Repo) string {
 	owner, repoName := parseGitHubRepo(repo.RemoteURL("upstream"))
 	if owner != "" {
-		if private, ok := ghRepoPrivate(owner, repoName); ok && private {
+		if private, ok := ghRepoPrivate(repo.Context(), owner, repoName); ok && private {
 			return "upstream"
 		}
 	}
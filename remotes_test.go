@@ -11,7 +11,7 @@ func TestBranchTrackingSuppressedForOrphan(t *testing.T) {
 	r.git("remote", "add", "origin", "git@github.com:me/repo.git")
 	r.git("remote", "add", "nirs", "https://github.com/nirs/repo.git")
 	// Cache the fork-parent lookup so RemoteCheck stays offline.
-	r.git("config", "remote.origin.gh-parent", "none")
+	r.cacheForkParent("none")
 	r.reload()
 
 	// An orphan branch by another author that tracks the non-origin remote.
@@ -49,7 +49,7 @@ func forkRepo(t *testing.T) *testRepo {
 	r.commit("a.txt", "a", "first", time.Now())
 	r.git("remote", "add", "origin", "https://github.com/me/repo.git")
 	r.git("remote", "add", "upstream", "https://github.com/acme/repo.git")
-	r.git("config", "remote.origin.gh-parent", "none")
+	r.cacheForkParent("none")
 	return r
 }
 
@@ -134,7 +134,7 @@ func TestRemoteUpstreamPushURLFixable(t *testing.T) {
 	r.git("remote", "add", "origin", "git@github.com:me/repo.git")
 	r.git("remote", "add", "upstream", "git@github.com:acme/repo.git")
 	// Cache the fork-parent lookups as "none" so the check never calls gh.
-	r.git("config", "remote.origin.gh-parent", "none")
+	r.cacheForkParent("none")
 	r.git("config", "remote.upstream.gh-parent", "none")
 	r.reload()
 
@@ -165,14 +165,22 @@ func TestHasRemote(t *testing.T) {
 }
 
 func TestWorkOrgInURL(t *testing.T) {
-	orgs := []string{"acme"}
+	orgs := []string{"acme", "corp-*"}
 	tests := []struct {
 		url  string
 		want string
 	}{
 		{"https://github.com/acme/repo.git", "acme"},
 		{"git@github.com:acme/repo.git", "acme"},
+		{"ssh://git@github.com/Acme/repo.git", "acme"},
 		{"https://github.com/personal/repo.git", ""},
+		{"git@github.com:corp-eng/repo.git", "corp-*"},
+		{"https://github.com/corp/repo.git", ""},
+		// The owner must match, not just a path segment elsewhere.
+		{"https://github.com/me/acme/repo.git", ""},
+		{"https://github.com/acme-staging/repo.git", ""},
+		{"https://github.com/me/acme.git", ""},
+		{"https://gitlab.com/acme/repo.git", ""},
 	}
 	for _, tt := range tests {
 		if got := workOrgInURL(tt.url, orgs); got != tt.want {
@@ -190,3 +198,29 @@ func TestBranchExists(t *testing.T) {
 		t.Error("branchExists(missing) = true, want false")
 	}
 }
+
+func TestForkWorkflowRollup(t *testing.T) {
+	r := forkRepo(t)
+	r.cacheForkParent("acme/repo")
+	r.Config.WorkOrgs = []string{"acme"}
+	r.reload()
+
+	results := (&RemoteCheck{}).Check(r.Repo)
+	got, ok := resultByName(results, "remote/fork-workflow")
+	if !ok || got.Status != StatusFail || len(got.Details) != 3 {
+		t.Fatalf("fork-workflow before fix = %+v, want fail itemizing gh-resolved, tracking, push-guard", got)
+	}
+	for _, part := range []string{"remote/gh-resolved", "remote/tracking", "remote/push-guard"} {
+		if _, ok := resultByName(results, part); !ok {
+			t.Errorf("detailed result %s missing alongside the roll-up", part)
+		}
+	}
+
+	fixed := (&RemoteCheck{}).Fix(r.Repo, results)
+	if got, _ := resultByName(fixed, "remote/fork-workflow"); got.Status != StatusOK {
+		t.Errorf("fork-workflow after fix = %+v, want ok", got)
+	}
+	if got, _ := resultByName((&RemoteCheck{}).Check(r.Repo), "remote/fork-workflow"); got.Status != StatusOK {
+		t.Errorf("fork-workflow on re-check = %+v, want ok", got)
+	}
+}
@@ -1,10 +1,13 @@
 package main
 
 import (
+	"context"
 	"errors"
-	"os/exec"
+	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 )
 
 var errNotARepo = errors.New("not a git repository")
@@ -14,35 +17,101 @@ type Repo struct {
 	Config *Config
 	Work   bool // true if any remote URL matches a work org
 
-	mainBranch    string
-	mainBranchSet bool
+	// WorkReason explains a work classification, e.g. which remote matched
+	// which org. Empty for personal repos.
+	WorkReason string
+
+	// Ref, when set by --ref, makes tree- and history-based checks inspect
+	// that ref instead of the working tree. Checks that only make sense for
+	// a working tree report themselves as not applicable.
+	Ref string
+
+	// Staged, set by --staged, makes content checks look only at the
+	// files staged for the next commit.
+	Staged bool
+
+	mainBranch     string
+	mainBranchOnce sync.Once
+
+	// cacheMu serializes lookups that cache their result in git config
+	// (ForkParent, OriginReadOnly, upstreamDefaultBranch), so checks running
+	// concurrently neither repeat the lookup nor race on .git/config.lock.
+	cacheMu sync.Mutex
+	// configMu serializes writes to .git/config.
+	configMu sync.Mutex
+
+	// RefreshCache (--refresh-cache) makes cached GitHub lookups query
+	// again regardless of their age.
+	RefreshCache bool
+	// forkParentRefreshed records that ForkParent already queried GitHub
+	// in this run; guarded by cacheMu.
+	forkParentRefreshed bool
+
+	// DryRun (--fix-dry-run) makes the mutation helpers record what they
+	// would change instead of changing it; see dryrun.go.
+	DryRun    bool
+	intents   []string
+	touched   map[string]bool // paths fixes changed; see touch
+	intentsMu sync.Mutex
+
+	// ctx bounds the repo's git and gh commands and logs their timeouts
+	// in timeouts; see Context.
+	ctx      context.Context
+	timeouts timeoutLog
 }
 
 func NewRepo(dir string, cfg *Config) (*Repo, error) {
 	r := &Repo{Dir: dir, Config: cfg}
+	r.ctx = withTimeoutLog(context.Background(), &r.timeouts)
 	if _, err := r.Git("rev-parse", "--git-dir"); err != nil {
 		return nil, errNotARepo
 	}
+	if cfg.TrustLocalConfig {
+		if err := r.applyLocalConfig(); err != nil {
+			return nil, err
+		}
+	}
 	if err := r.classify(); err != nil {
 		return nil, err
 	}
 	return r, nil
 }
 
+// applyLocalConfig merges the repo's .git-lint.json, if any, over the
+// config this repo is checked with.
+func (r *Repo) applyLocalConfig() error {
+	top, err := r.Git("rev-parse", "--show-toplevel")
+	if err != nil || top == "" {
+		return nil
+	}
+	path := filepath.Join(top, localConfigFile)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	merged, err := mergeConfig(r.Config, data)
+	if err != nil {
+		return fmt.Errorf("parsing %s: %w", path, err)
+	}
+	r.Config = merged
+	return nil
+}
+
 func (r *Repo) classify() error {
 	remotes, err := r.Remotes()
 	if err != nil {
 		return err
 	}
 	for _, name := range remotes {
-		url := r.RemoteURL(name)
-		for _, org := range r.Config.WorkOrgs {
-			// Match github.com/org/ in any remote URL (both HTTPS and SSH).
-			if strings.Contains(url, "github.com/"+org+"/") ||
-				strings.Contains(url, "github.com:"+org+"/") {
-				r.Work = true
-				return nil
-			}
+		// Match the GitHub owner of any remote URL (both HTTPS and SSH)
+		// against the configured orgs, which may be globs.
+		if org := workOrgInURL(r.RemoteURL(name), r.Config.WorkOrgs); org != "" {
+			r.Work = true
+			r.WorkReason = fmt.Sprintf("remote %s matches work org %s", name, org)
+			return nil
 		}
 	}
 
@@ -50,11 +119,28 @@ func (r *Repo) classify() error {
 }
 
 // Git runs a git command in the repo directory and returns trimmed stdout.
+// In dry-run mode, commands that would change the repo are recorded instead.
 func (r *Repo) Git(args ...string) (string, error) {
-	cmd := exec.Command("git", args...)
-	cmd.Dir = r.Dir
-	out, err := cmd.Output()
-	return strings.TrimRight(string(out), "\n"), err
+	if r.DryRun && gitMutates(args) {
+		r.record("git %s", strings.Join(args, " "))
+		return "", nil
+	}
+	return runCommand(r.Context(), r.Dir, "git", args...)
+}
+
+// Context returns the context for the repo's subprocesses. Commands run
+// with it that time out are reported by TimeoutResults.
+func (r *Repo) Context() context.Context {
+	if r.ctx == nil {
+		return context.Background()
+	}
+	return r.ctx
+}
+
+// TimeoutResults returns a warning listing the commands that timed out
+// while checking the repo, if any.
+func (r *Repo) TimeoutResults() []Result {
+	return r.timeouts.results()
 }
 
 // GitConfig reads a single local git config value from .git/config.
@@ -72,14 +158,48 @@ func (r *Repo) GitConfigEffective(key string) string {
 
 // SetGitConfig sets a local git config value.
 func (r *Repo) SetGitConfig(key, value string) error {
-	_, err := r.Git("config", key, value)
-	return err
+	if r.DryRun {
+		r.record("git config %s %s", key, value)
+		return nil
+	}
+	r.configMu.Lock()
+	defer r.configMu.Unlock()
+	if _, err := r.Git("config", key, value); err != nil {
+		return err
+	}
+	r.touch(gitPath(r, "config"))
+	return nil
 }
 
 // UnsetGitConfig removes a local git config value.
 func (r *Repo) UnsetGitConfig(key string) error {
-	_, err := r.Git("config", "--unset", key)
-	return err
+	if r.DryRun {
+		if r.GitConfig(key) != "" {
+			r.record("git config --unset %s", key)
+		}
+		return nil
+	}
+	r.configMu.Lock()
+	defer r.configMu.Unlock()
+	if _, err := r.Git("config", "--unset", key); err != nil {
+		return err
+	}
+	r.touch(gitPath(r, "config"))
+	return nil
+}
+
+// IgnoredResults returns the result names listed in the multi-valued
+// lint.ignore git config, e.g. "branch/orphan[demo]".
+func (r *Repo) IgnoredResults() map[string]bool {
+	out, err := r.Git("config", "--get-all", "lint.ignore")
+	if err != nil || out == "" {
+		return nil
+	}
+	ignored := make(map[string]bool)
+	for _, name := range strings.Split(out, "\n") {
+		ignored[strings.TrimSpace(name)] = true
+	}
+	return ignored
 }
 
 // Remotes returns the list of remote names.
@@ -100,25 +220,42 @@ func (r *Repo) RemoteURL(name string) string {
 	return r.GitConfig("remote." + name + ".url")
 }
 
-// MainBranch returns the name of the default branch. It prefers a local
-// "main" or "master"; in a fork that has neither, it returns the local branch
-// matching the upstream's default branch, covering custom default-branch
-// names. Returns "" if none is found. The result is memoized.
+// MainBranch returns the name of the default branch: the first local branch
+// among origin's default branch, init.defaultBranch, the mainBranches
+// config, main, and master, or in a fork with none of them the branch
+// matching the upstream's default (see computeMainBranch). Returns "" if
+// none is found. The result is memoized and safe for concurrent use.
 func (r *Repo) MainBranch() string {
-	if !r.mainBranchSet {
+	r.mainBranchOnce.Do(func() {
 		r.mainBranch = r.computeMainBranch()
-		r.mainBranchSet = true
-	}
+	})
 	return r.mainBranch
 }
 
+// resetMainBranch forgets the cached main branch after a fix renames it.
+// Fixes run sequentially, so nothing reads it concurrently.
+func (r *Repo) resetMainBranch() {
+	r.mainBranchOnce = sync.Once{}
+	r.mainBranch = ""
+}
+
+// computeMainBranch returns the first candidate that exists as a local
+// branch: origin's default branch (origin/HEAD), the effective
+// init.defaultBranch, the configured mainBranches, then main and master.
 func (r *Repo) computeMainBranch() string {
-	for _, name := range []string{"main", "master"} {
-		if r.hasLocalBranch(name) {
+	var candidates []string
+	if ref, err := r.Git("symbolic-ref", "--quiet", "--short", "refs/remotes/origin/HEAD"); err == nil {
+		candidates = append(candidates, strings.TrimPrefix(ref, "origin/"))
+	}
+	candidates = append(candidates, r.GitConfigEffective("init.defaultBranch"))
+	candidates = append(candidates, r.Config.MainBranches...)
+	candidates = append(candidates, "main", "master")
+	for _, name := range candidates {
+		if name != "" && r.hasLocalBranch(name) {
 			return name
 		}
 	}
-	// Custom default branch: a fork whose default is neither main nor master.
+	// A fork whose default branch is none of the candidates.
 	if !r.hasRemoteNamed("upstream") {
 		return ""
 	}
@@ -130,7 +267,15 @@ func (r *Repo) computeMainBranch() string {
 
 // hasLocalBranch reports whether a local branch with the given name exists.
 func (r *Repo) hasLocalBranch(name string) bool {
-	return exec.Command("git", "-C", r.Dir, "rev-parse", "--verify", "--quiet", "refs/heads/"+name).Run() == nil
+	_, err := r.Git("rev-parse", "--verify", "--quiet", "refs/heads/"+name)
+	return err == nil
+}
+
+// hasRemoteBranch reports whether the remote-tracking branch remote/name
+// exists.
+func (r *Repo) hasRemoteBranch(remote, name string) bool {
+	_, err := r.Git("rev-parse", "--verify", "--quiet", "refs/remotes/"+remote+"/"+name)
+	return err == nil
 }
 
 // hasRemoteNamed reports whether a remote with the given name is configured.
@@ -143,6 +288,8 @@ func (r *Repo) hasRemoteNamed(name string) bool {
 // reads the local upstream/HEAD symref first, then a cached value, and only as
 // a last resort queries the remote over the network, caching the result.
 func (r *Repo) upstreamDefaultBranch() string {
+	r.cacheMu.Lock()
+	defer r.cacheMu.Unlock()
 	if ref, err := r.Git("symbolic-ref", "--short", "refs/remotes/upstream/HEAD"); err == nil && ref != "" {
 		return strings.TrimPrefix(ref, "upstream/")
 	}
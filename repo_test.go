@@ -53,3 +53,65 @@ func TestMainBranchCustomDefaultFromCachedConfig(t *testing.T) {
 		t.Errorf("MainBranch() = %q, want trunk (from cached config)", got)
 	}
 }
+
+func TestRepoKind(t *testing.T) {
+	r := newTestRepo(t)
+	if got := repoKind(r.Repo, false); got != "personal" {
+		t.Errorf("repoKind = %q, want personal", got)
+	}
+
+	r.git("remote", "add", "origin", "git@github.com:acme/repo.git")
+	r.Config.WorkOrgs = []string{"acme"}
+	r.reload()
+	if got := repoKind(r.Repo, false); got != "work" {
+		t.Errorf("repoKind = %q, want work", got)
+	}
+	if got, want := repoKind(r.Repo, true), "work (remote origin matches work org acme)"; got != want {
+		t.Errorf("repoKind verbose = %q, want %q", got, want)
+	}
+}
+
+func TestIgnoredResults(t *testing.T) {
+	r := newTestRepo(t)
+	if got := r.IgnoredResults(); len(got) != 0 {
+		t.Errorf("IgnoredResults = %v, want none", got)
+	}
+	r.git("config", "--add", "lint.ignore", "branch/orphan[demo]")
+	r.git("config", "--add", "lint.ignore", "hooks/local")
+	got := r.IgnoredResults()
+	if !got["branch/orphan[demo]"] || !got["hooks/local"] || len(got) != 2 {
+		t.Errorf("IgnoredResults = %v", got)
+	}
+}
+
+func TestMainBranchCandidates(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("a.txt", "a", "first", time.Now())
+	r.git("branch", "-m", "main", "develop")
+	r.git("branch", "trunk")
+	r.reload()
+	if got := r.MainBranch(); got != "" {
+		t.Fatalf("MainBranch() = %q, want none without main or master", got)
+	}
+
+	r.Config.MainBranches = []string{"release", "trunk", "develop"}
+	r.reload()
+	if got := r.MainBranch(); got != "trunk" {
+		t.Errorf("MainBranch() = %q, want trunk (first configured that exists)", got)
+	}
+
+	r.git("config", "init.defaultBranch", "develop")
+	r.reload()
+	if got := r.MainBranch(); got != "develop" {
+		t.Errorf("MainBranch() = %q, want develop (init.defaultBranch)", got)
+	}
+
+	// origin/HEAD wins over everything else.
+	r.git("remote", "add", "origin", "https://github.com/me/repo.git")
+	r.git("update-ref", "refs/remotes/origin/trunk", r.git("rev-parse", "HEAD"))
+	r.git("symbolic-ref", "refs/remotes/origin/HEAD", "refs/remotes/origin/trunk")
+	r.reload()
+	if got := r.MainBranch(); got != "trunk" {
+		t.Errorf("MainBranch() = %q, want trunk (origin/HEAD)", got)
+	}
+}
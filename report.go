@@ -0,0 +1,97 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// jsonReport is the single document written by --format json (and rendered
+// as XML for --format junit, or as rows for --format tsv). It holds one entry
+// per checked repo and a summary using the probe's counts, so a recursive run
+// produces one self-contained artifact.
+type jsonReport struct {
+	Repos   []jsonRepo  `json:"repos"`
+	Summary repoSummary `json:"summary"`
+	Stalest []repoAge   `json:"stalest,omitempty"` // fleet staleness outliers (-R with fleetStaleAfter)
+}
+
+type jsonRepo struct {
+	Name    string   `json:"name"`
+	Status  string   `json:"status"` // probe status: ok, warning, critical
+	Results []Result `json:"results"`
+}
+
+// resultsReport is the document --json writes with -R.
+type resultsReport struct {
+	Repos   map[string][]Result `json:"repos"`
+	Summary repoSummary         `json:"summary"`
+}
+
+func newJSONReport() *jsonReport {
+	return &jsonReport{
+		Repos:   []jsonRepo{},
+		Summary: newRepoSummary(),
+	}
+}
+
+// add records a repo's results and updates the summary counts.
+func (rep *jsonReport) add(name string, results []Result) {
+	if results == nil {
+		results = []Result{}
+	}
+	status := rep.Summary.add(name, results)
+	rep.Repos = append(rep.Repos, jsonRepo{Name: name, Status: status, Results: results})
+}
+
+// writeFormat writes the report as "json", "junit", "tsv", "stat",
+// "metrics", or "results".
+func (rep *jsonReport) writeFormat(w io.Writer, format string) error {
+	switch format {
+	case "junit":
+		return rep.writeJUnit(w)
+	case "tsv":
+		return rep.writeTSV(w)
+	case "stat":
+		return rep.writeStat(w)
+	case "metrics":
+		return rep.writeMetrics(w)
+	case "results":
+		// --json with -R: each repo's results keyed by directory name,
+		// next to the summary.
+		byRepo := map[string][]Result{}
+		for _, repo := range rep.Repos {
+			byRepo[repo.Name] = repo.Results
+		}
+		return writeJSON(w, resultsReport{Repos: byRepo, Summary: rep.Summary})
+	}
+	return rep.write(w)
+}
+
+// writeStat writes the summary counts as one line for --stat, plus how many
+// warnings and failures --fix could resolve.
+func (rep *jsonReport) writeStat(w io.Writer) error {
+	fixable := 0
+	for _, repo := range rep.Repos {
+		for _, r := range repo.Results {
+			if r.Fixable && (r.Status == StatusWarn || r.Status == StatusFail) {
+				fixable++
+			}
+		}
+	}
+	s := rep.Summary
+	_, err := fmt.Fprintf(w, "%d checked, %d ok, %d warned, %d failed, %d fixable\n",
+		s.ReposChecked, s.ReposOK, s.ReposWarned, s.ReposFailed, fixable)
+	return err
+}
+
+func (rep *jsonReport) write(w io.Writer) error {
+	return writeJSON(w, rep)
+}
+
+// writeJSON writes v as indented JSON.
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
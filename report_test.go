@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestJSONReportEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := newJSONReport().write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON %q: %v", buf.String(), err)
+	}
+	if repos, ok := got["repos"].([]any); !ok || len(repos) != 0 {
+		t.Errorf("repos = %v, want empty array", got["repos"])
+	}
+	summary := got["summary"].(map[string]any)
+	if summary["repos_checked"] != float64(0) || summary["worst_status"] != "ok" {
+		t.Errorf("summary = %v, want zero counts and ok", summary)
+	}
+}
+
+func TestJSONReportSummary(t *testing.T) {
+	rep := newJSONReport()
+	rep.add("clean", []Result{{Name: "identity/name", Status: StatusOK}})
+	rep.add("warned", []Result{{Name: "branch/merged[x]", Status: StatusWarn}})
+	rep.add("failed", []Result{{Name: "identity/email", Status: StatusFail}})
+
+	want := repoSummary{ReposChecked: 3, ReposOK: 1, ReposWarned: 1, ReposFailed: 1, WorstStatus: "critical", Failed: []string{"failed"}}
+	if !reflect.DeepEqual(rep.Summary, want) {
+		t.Errorf("summary = %+v, want %+v", rep.Summary, want)
+	}
+	if rep.Repos[1].Status != "warning" {
+		t.Errorf("repo status = %q, want warning", rep.Repos[1].Status)
+	}
+}
+
+func TestStatReport(t *testing.T) {
+	rep := newJSONReport()
+	rep.add("clean", []Result{{Name: "identity/name", Status: StatusOK}})
+	rep.add("warned", []Result{{Name: "branch/merged[x]", Status: StatusWarn, Fixable: true}})
+	rep.add("failed", []Result{
+		{Name: "identity/email", Status: StatusFail, Fixable: true},
+		{Name: "remote/origin", Status: StatusFail},
+	})
+
+	var buf bytes.Buffer
+	if err := rep.writeFormat(&buf, "stat"); err != nil {
+		t.Fatal(err)
+	}
+	if want := "3 checked, 1 ok, 1 warned, 1 failed, 2 fixable\n"; buf.String() != want {
+		t.Errorf("stat = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestResultsReportKeyedByRepo(t *testing.T) {
+	rep := newJSONReport()
+	rep.add("alpha", []Result{{Name: "identity/email", Status: StatusFix, Message: "set to a@example.com"}})
+	rep.add("beta", nil)
+
+	var buf bytes.Buffer
+	if err := rep.writeFormat(&buf, "results"); err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		Repos   map[string][]Result `json:"repos"`
+		Summary repoSummary         `json:"summary"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid JSON %q: %v", buf.String(), err)
+	}
+	got := doc.Repos
+	if doc.Summary.ReposChecked != 2 || doc.Summary.ReposOK != 2 {
+		t.Errorf("summary = %+v, want 2 ok repos", doc.Summary)
+	}
+	if len(got) != 2 || len(got["alpha"]) != 1 || got["alpha"][0].Status != StatusFix {
+		t.Errorf("results = %+v, want alpha with its fixed result and beta", got)
+	}
+	if got["beta"] == nil {
+		t.Errorf("beta results = nil, want empty array")
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// RequiredTrackedCheck fails when an ignore rule matches a file the project
+// needs tracked, such as a broad "*.yml" hiding a required config. The files
+// come from the requiredTrackedFiles config (globs relative to the repo
+// root); the check does nothing when the list is empty.
+type RequiredTrackedCheck struct{}
+
+func (c *RequiredTrackedCheck) Name() string { return "required-tracked" }
+
+func (c *RequiredTrackedCheck) Check(repo *Repo) []Result {
+	patterns := repo.Config.RequiredTrackedFiles
+	if len(patterns) == 0 {
+		return nil
+	}
+	if repo.Ref != "" {
+		// Ignore rules and glob expansion both come from the working tree.
+		return refNotApplicable("files/required-ignored")
+	}
+
+	var paths []string
+	for _, pattern := range patterns {
+		paths = append(paths, expandRequired(repo.Dir, pattern)...)
+	}
+	if len(paths) == 0 {
+		return nil
+	}
+
+	// --no-index also tests tracked files, which check-ignore otherwise
+	// skips; a tracked file matched by a rule is still a latent problem
+	// (a fresh copy would not get added).
+	args := append([]string{"check-ignore", "--verbose", "--no-index", "--"}, paths...)
+	out, _ := repo.Git(args...) // exits 1 when nothing is ignored
+
+	var details []string
+	for _, line := range strings.Split(out, "\n") {
+		// Format: <source>:<line>:<pattern>\t<path>
+		rule, path, ok := strings.Cut(line, "\t")
+		if !ok {
+			continue
+		}
+		// --verbose also reports paths whose last matching rule is a
+		// negation (!pattern); those are re-included, not ignored.
+		if f := strings.SplitN(rule, ":", 3); len(f) == 3 && strings.HasPrefix(f[2], "!") {
+			continue
+		}
+		details = append(details, fmt.Sprintf("%s (ignored by %s)", path, rule))
+	}
+
+	if len(details) > 0 {
+		return []Result{{
+			Name:    "files/required-ignored",
+			Status:  StatusFail,
+			Message: fmt.Sprintf("%d required files match ignore rules", len(details)),
+			Details: details,
+		}}
+	}
+	return []Result{{
+		Name:    "files/required-ignored",
+		Status:  StatusOK,
+		Message: "no required files are ignored",
+	}}
+}
+
+func (c *RequiredTrackedCheck) Fix(_ *Repo, results []Result) []Result {
+	return results
+}
+
+// expandRequired expands a requiredTrackedFiles glob against the working
+// tree, returning slash-separated paths relative to dir. A pattern without
+// glob characters is returned as-is even if the file is missing, since
+// check-ignore can still test it.
+func expandRequired(dir, pattern string) []string {
+	matches, _ := filepath.Glob(filepath.Join(dir, filepath.FromSlash(pattern)))
+	if len(matches) == 0 {
+		if strings.ContainsAny(pattern, "*?[") {
+			return nil
+		}
+		return []string{pattern}
+	}
+	var paths []string
+	for _, m := range matches {
+		if rel, err := filepath.Rel(dir, m); err == nil {
+			paths = append(paths, filepath.ToSlash(rel))
+		}
+	}
+	return paths
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRequiredTrackedCheck(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit(".gitignore", "*.yml\n", "ignore yaml", time.Now())
+	if err := os.WriteFile(filepath.Join(r.dir, "config.yml"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if results := (&RequiredTrackedCheck{}).Check(r.Repo); len(results) != 0 {
+		t.Errorf("no required files configured: got %+v, want none", results)
+	}
+
+	r.Config.RequiredTrackedFiles = []string{"*.yml", "README.md"}
+	got, ok := resultByName((&RequiredTrackedCheck{}).Check(r.Repo), "files/required-ignored")
+	if !ok || got.Status != StatusFail || len(got.Details) != 1 {
+		t.Fatalf("files/required-ignored = %+v, want fail with one detail", got)
+	}
+	if want := "config.yml (ignored by .gitignore:1:*.yml)"; got.Details[0] != want {
+		t.Errorf("detail = %q, want %q", got.Details[0], want)
+	}
+}
+
+func TestRequiredTrackedCheckNegatedRule(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit(".gitignore", "*.yml\n!needed.yml\n", "ignore yaml but one", time.Now())
+	if err := os.WriteFile(filepath.Join(r.dir, "needed.yml"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	r.Config.RequiredTrackedFiles = []string{"needed.yml"}
+	if got, _ := resultByName((&RequiredTrackedCheck{}).Check(r.Repo), "files/required-ignored"); got.Status != StatusOK {
+		t.Errorf("re-included file = %+v, want ok", got)
+	}
+}
@@ -9,6 +9,8 @@ import (
 
 type ReviewsCheck struct{}
 
+func (c *ReviewsCheck) Name() string { return "reviews" }
+
 func (c *ReviewsCheck) Check(repo *Repo) []Result {
 	worktree := filepath.Join(repo.Dir, ".reviews")
 	if _, err := os.Stat(worktree); err != nil {
@@ -22,7 +24,7 @@ func (c *ReviewsCheck) Check(repo *Repo) []Result {
 		return nil
 	}
 
-	unpushed, err := gitInDir(worktree, "log", "@{upstream}..HEAD", "--oneline")
+	unpushed, err := gitInDir(repo.Context(), worktree, "log", "@{upstream}..HEAD", "--oneline")
 	if err != nil || unpushed == "" {
 		return nil
 	}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// findRepos returns the git repos under root, as slash-separated paths
+// relative to root in walk order. It descends up to depth directory levels
+// (1 means only root's immediate subdirectories) and stops at the first
+// directory containing .git, so submodules and nested checkouts are not
+// counted separately. Bare repos have no working tree to check and are
+// skipped along with everything under them, as are directories matching
+// one of excludes (see excludedDir).
+func findRepos(root string, depth int, excludes []string) ([]string, error) {
+	var repos []string
+	var walk func(rel string, level int) error
+	walk = func(rel string, level int) error {
+		entries, err := os.ReadDir(filepath.Join(root, filepath.FromSlash(rel)))
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				continue
+			}
+			child := path.Join(rel, entry.Name())
+			if excludedDir(child, excludes) {
+				continue
+			}
+			dir := filepath.Join(root, filepath.FromSlash(child))
+			if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+				repos = append(repos, child)
+				continue
+			}
+			if level >= depth || isBareRepo(dir) {
+				continue
+			}
+			// Unreadable subdirectories are skipped rather than failing
+			// the whole scan.
+			_ = walk(child, level+1)
+		}
+		return nil
+	}
+	if err := walk("", 1); err != nil {
+		return nil, err
+	}
+	return repos, nil
+}
+
+// isBareRepo reports whether dir looks like a bare repo: HEAD, objects, and
+// refs at the top level.
+func isBareRepo(dir string) bool {
+	for _, name := range []string{"HEAD", "objects", "refs"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// excludedDir reports whether the directory at rel, a slash-separated path
+// relative to the scan root, matches one of patterns. Patterns use
+// filepath.Match syntax against the relative path; a pattern without a slash
+// also matches the directory's base name at any level, like "node_modules".
+func excludedDir(rel string, patterns []string) bool {
+	for _, pattern := range patterns {
+		pattern = strings.TrimSuffix(pattern, "/")
+		if ok, _ := filepath.Match(pattern, rel); ok {
+			return true
+		}
+		if !strings.Contains(pattern, "/") {
+			if ok, _ := filepath.Match(pattern, path.Base(rel)); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
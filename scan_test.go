@@ -0,0 +1,66 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestFindRepos(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{
+		"top/.git",                         // repo at depth 1
+		"top/vendor/nested/.git",           // inside a repo: not counted
+		"github.com/acme/app/.git",         // repo at depth 3
+		"github.com/acme/archive.git/HEAD", // bare repo: skipped
+		"github.com/acme/archive.git/objects",
+		"github.com/acme/archive.git/refs",
+		"notes/plain", // no repo at all
+	} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// A submodule-style .git file also marks a repo.
+	if err := os.MkdirAll(filepath.Join(root, "linked"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "linked", ".git"), []byte("gitdir: ../x\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		depth int
+		want  []string
+	}{
+		{1, []string{"linked", "top"}},
+		{2, []string{"linked", "top"}},
+		{3, []string{"github.com/acme/app", "linked", "top"}},
+	}
+	for _, tt := range tests {
+		got, err := findRepos(root, tt.depth, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("findRepos(depth %d) = %q, want %q", tt.depth, got, tt.want)
+		}
+	}
+}
+
+func TestFindReposExclude(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{"app/.git", "vendor/lib/.git", "archive/old/.git", "src/node_modules/pkg/.git"} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got, err := findRepos(root, 3, []string{"vendor/", "archive/*", "node_modules"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"app"}; !slices.Equal(got, want) {
+		t.Errorf("findRepos with excludes = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SigningCheck verifies that work repos sign commits: commit.gpgSign is
+// enabled in the effective config. The fix enables it locally when
+// user.signingKey names a key to sign with (for gpg.format=ssh, the key
+// file); without one it can only warn.
+type SigningCheck struct{}
+
+func (c *SigningCheck) Name() string { return "signing" }
+
+func (c *SigningCheck) Check(repo *Repo) []Result {
+	if !repo.Work {
+		return nil
+	}
+	if enabled, _ := repo.Git("config", "--type=bool", "--get", "commit.gpgSign"); enabled == "true" {
+		return []Result{{
+			Name:    "signing/enabled",
+			Status:  StatusOK,
+			Message: "commit signing is enabled",
+		}}
+	}
+	if repo.GitConfigEffective("user.signingKey") == "" {
+		return []Result{{
+			Name:    "signing/enabled",
+			Status:  StatusWarn,
+			Message: "work commits are not signed, and no user.signingKey is configured",
+		}}
+	}
+	return []Result{{
+		Name:    "signing/enabled",
+		Status:  StatusWarn,
+		Message: "work commits are not signed (commit.gpgSign is off)",
+		Fixable: true,
+	}}
+}
+
+func (c *SigningCheck) Fix(repo *Repo, results []Result) []Result {
+	var fixed []Result
+	for _, r := range results {
+		if r.Status != StatusWarn || !r.Fixable {
+			fixed = append(fixed, r)
+			continue
+		}
+		if err := repo.SetGitConfig("commit.gpgSign", "true"); err != nil {
+			fixed = append(fixed, r)
+			continue
+		}
+		fixed = append(fixed, Result{
+			Name:    r.Name,
+			Status:  StatusFix,
+			Message: "set commit.gpgSign=true",
+		})
+	}
+	return fixed
+}
+
+// SigningKeyCheck verifies the signatures on unpushed commits. A commit can
+// carry a signature that no longer verifies because the key expired, was
+// revoked, or is unknown locally; pushing it publishes a signature others
+// cannot trust. The check only runs when commit.gpgSign is enabled.
+type SigningKeyCheck struct{}
+
+func (c *SigningKeyCheck) Name() string { return "signing-key" }
+
+func (c *SigningKeyCheck) Check(repo *Repo) []Result {
+	if enabled, _ := repo.Git("config", "--type=bool", "--get", "commit.gpgSign"); enabled != "true" {
+		return nil
+	}
+
+	// %G? runs the same verification as git verify-commit.
+	out, err := repo.Git("log", "--branches", "--not", "--remotes", "--format=%G? %h %s")
+	if err != nil || out == "" {
+		return nil
+	}
+
+	var details []string
+	for _, line := range strings.Split(out, "\n") {
+		code, rest, ok := strings.Cut(line, " ")
+		if !ok {
+			continue
+		}
+		if problem := signatureProblem(code); problem != "" {
+			details = append(details, fmt.Sprintf("%s (%s)", rest, problem))
+		}
+	}
+
+	if len(details) > 0 {
+		return []Result{{
+			Name:    "signing/key",
+			Status:  StatusWarn,
+			Message: fmt.Sprintf("%d unpushed signed commits do not verify", len(details)),
+			Details: details,
+		}}
+	}
+	return []Result{{
+		Name:    "signing/key",
+		Status:  StatusOK,
+		Message: "unpushed signatures verify",
+	}}
+}
+
+func (c *SigningKeyCheck) Fix(_ *Repo, results []Result) []Result {
+	return results
+}
+
+// signatureProblem describes a %G? verification code for a commit that has
+// a signature which does not verify cleanly. Returns "" for good signatures
+// and for unsigned commits, which are a separate concern.
+func signatureProblem(code string) string {
+	switch code {
+	case "B":
+		return "bad signature"
+	case "X":
+		return "signature expired"
+	case "Y":
+		return "signing key expired"
+	case "R":
+		return "signing key revoked"
+	case "E":
+		return "signing key unknown"
+	}
+	return ""
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSignatureProblem(t *testing.T) {
+	tests := []struct {
+		code, want string
+	}{
+		{"G", ""},
+		{"U", ""},
+		{"N", ""},
+		{"Y", "signing key expired"},
+		{"R", "signing key revoked"},
+		{"E", "signing key unknown"},
+		{"B", "bad signature"},
+	}
+	for _, tt := range tests {
+		if got := signatureProblem(tt.code); got != tt.want {
+			t.Errorf("signatureProblem(%q) = %q, want %q", tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestSigningKeyCheckRequiresSigning(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("a.txt", "a", "first", time.Now())
+	if results := (&SigningKeyCheck{}).Check(r.Repo); len(results) != 0 {
+		t.Errorf("signing disabled: got %+v, want none", results)
+	}
+}
+
+func TestSigningCheckWorkRepos(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("a.txt", "a", "first", time.Now())
+	r.git("remote", "add", "origin", "https://github.com/acme/repo.git")
+	r.reload()
+	if results := (&SigningCheck{}).Check(r.Repo); len(results) != 0 {
+		t.Errorf("personal repo: got %+v, want none", results)
+	}
+
+	r.Config.WorkOrgs = []string{"acme"}
+	r.reload()
+	got, _ := resultByName((&SigningCheck{}).Check(r.Repo), "signing/enabled")
+	if got.Status != StatusWarn || got.Fixable {
+		t.Errorf("no signing key = %+v, want non-fixable warn", got)
+	}
+
+	r.git("config", "gpg.format", "ssh")
+	r.git("config", "user.signingKey", "~/.ssh/id_ed25519.pub")
+	results := (&SigningCheck{}).Check(r.Repo)
+	if got, _ := resultByName(results, "signing/enabled"); got.Status != StatusWarn || !got.Fixable {
+		t.Fatalf("with signing key = %+v, want fixable warn", got)
+	}
+	fixed := (&SigningCheck{}).Fix(r.Repo, results)
+	if got, _ := resultByName(fixed, "signing/enabled"); got.Status != StatusFix {
+		t.Errorf("after fix = %+v, want fix", got)
+	}
+	if v := r.git("config", "--local", "commit.gpgSign"); v != "true" {
+		t.Errorf("commit.gpgSign = %q, want true", v)
+	}
+	if got, _ := resultByName((&SigningCheck{}).Check(r.Repo), "signing/enabled"); got.Status != StatusOK {
+		t.Errorf("re-check = %+v, want ok", got)
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// SquashAuthorsCheck lists the distinct authors on the user's feature
+// branches, warning when a branch has more than one: a squash merge would
+// credit all of its commits to a single person. The check is advisory and
+// opt-in via the squashAuthors config setting, for maintainers who squash.
+type SquashAuthorsCheck struct{}
+
+func (c *SquashAuthorsCheck) Name() string { return "squash-authors" }
+
+func (c *SquashAuthorsCheck) Check(repo *Repo) []Result {
+	if !repo.Config.SquashAuthors {
+		return nil
+	}
+	mainBranch := repo.MainBranch()
+	if mainBranch == "" {
+		return nil
+	}
+	out, err := repo.Git("for-each-ref", "--format=%(refname:short)|%(authorname)", "refs/heads/")
+	if err != nil {
+		return nil
+	}
+
+	var results []Result
+	for _, line := range strings.Split(out, "\n") {
+		branch, tipAuthor, ok := strings.Cut(line, "|")
+		if !ok || branch == mainBranch || tipAuthor != repo.Config.Identity.Name {
+			continue
+		}
+		log, err := repo.Git("log", "--format=%an <%ae>", mainBranch+".."+branch)
+		if err != nil || log == "" {
+			continue
+		}
+		counts := map[string]int{}
+		var authors []string
+		for _, author := range strings.Split(log, "\n") {
+			if counts[author] == 0 {
+				authors = append(authors, author)
+			}
+			counts[author]++
+		}
+		if len(authors) < 2 {
+			continue
+		}
+		slices.Sort(authors)
+		var details []string
+		for _, author := range authors {
+			details = append(details, fmt.Sprintf("%s: %d commits", author, counts[author]))
+		}
+		results = append(results, Result{
+			Name:    fmt.Sprintf("commits/authors[%s]", branch),
+			Status:  StatusWarn,
+			Message: fmt.Sprintf("%d authors ahead of %s; a squash merge credits only one", len(authors), mainBranch),
+			Details: details,
+		})
+	}
+	return results
+}
+
+func (c *SquashAuthorsCheck) Fix(_ *Repo, results []Result) []Result {
+	return results
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSquashAuthorsCheck(t *testing.T) {
+	r := newTestRepo(t)
+	now := time.Now()
+	r.commit("a.txt", "a", "base", now)
+	r.git("checkout", "-b", "feature")
+	r.commitAs("b.txt", "b", "their part", "Other Dev", "other@example.com", now)
+	r.commit("c.txt", "c", "my part", now)
+	r.commit("d.txt", "d", "more", now)
+	r.git("checkout", "main")
+
+	if results := (&SquashAuthorsCheck{}).Check(r.Repo); len(results) != 0 {
+		t.Fatalf("disabled: got %+v, want none", results)
+	}
+	r.Config.SquashAuthors = true
+
+	got, ok := resultByName((&SquashAuthorsCheck{}).Check(r.Repo), "commits/authors[feature]")
+	want := []string{"Other Dev <other@example.com>: 1 commits", "Test User <test@example.com>: 2 commits"}
+	if !ok || got.Status != StatusWarn || len(got.Details) != 2 || got.Details[0] != want[0] || got.Details[1] != want[1] {
+		t.Errorf("commits/authors = %+v, want warn listing %q", got, want)
+	}
+}
@@ -1,8 +1,12 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -14,6 +18,8 @@ type stashEntry struct {
 	display string
 }
 
+func (c *StalenessCheck) Name() string { return "staleness" }
+
 func (c *StalenessCheck) Check(repo *Repo) []Result {
 	var results []Result
 
@@ -67,59 +73,38 @@ func (c *StalenessCheck) Check(repo *Repo) []Result {
 	}
 
 	// Uncommitted changes and untracked files (per worktree).
-	maxUncommitted := repo.Config.Thresholds.UncommittedMaxAge.Duration
-	worktrees := listWorktrees(repo)
-	if len(worktrees) == 0 {
-		worktrees = []string{repo.Dir}
-	}
-	for _, wt := range worktrees {
-		results = append(results, worktreeStaleness(repo, wt, maxUncommitted)...)
+	if repo.Ref != "" {
+		results = append(results, refNotApplicable("staleness/uncommitted")...)
+		results = append(results, refNotApplicable("staleness/untracked")...)
+		return results
 	}
+	// Linked worktrees are left to WorktreeCheck.
+	maxUncommitted := repo.Config.Thresholds.UncommittedMaxAge.Duration
+	results = append(results, worktreeStaleness(repo, repo.Dir, maxUncommitted)...)
 
 	return results
 }
 
-// worktreeStaleness reports uncommitted/untracked staleness for one worktree.
-// Result names are suffixed with [<relpath>] for non-main worktrees so each
-// worktree appears as a separate row in the output.
+// worktreeStaleness reports uncommitted/untracked staleness for the worktree
+// at wt.
 func worktreeStaleness(repo *Repo, wt string, maxUncommitted time.Duration) []Result {
-	suffix := ""
-	if !sameDir(wt, repo.Dir) {
-		rel, err := filepath.Rel(canonPath(repo.Dir), canonPath(wt))
-		if err != nil {
-			rel = wt
-		}
-		suffix = fmt.Sprintf("[%s]", rel)
-	}
+	uncommittedLines, untrackedLines := worktreeChanges(repo, wt)
 
-	porcelain, _ := gitInDir(wt, "status", "--porcelain")
-	var uncommittedLines, untrackedLines []string
-	for _, line := range strings.Split(porcelain, "\n") {
-		if line == "" {
-			continue
-		}
-		if strings.HasPrefix(line, "?? ") {
-			untrackedLines = append(untrackedLines, line)
-		} else {
-			uncommittedLines = append(uncommittedLines, line)
-		}
-	}
-
-	age := uncommittedAge(wt)
+	age := uncommittedAge(repo.Context(), wt, append(uncommittedLines, untrackedLines...))
 	stale := age > maxUncommitted
 
 	var results []Result
 	if len(uncommittedLines) > 0 {
 		if stale {
 			results = append(results, Result{
-				Name:    "staleness/uncommitted" + suffix,
+				Name:    "staleness/uncommitted",
 				Status:  StatusFail,
 				Message: fmt.Sprintf("uncommitted changes for %s (max %s)", formatDuration(age), formatDuration(maxUncommitted)),
 				Details: uncommittedLines,
 			})
 		} else {
 			results = append(results, Result{
-				Name:    "staleness/uncommitted" + suffix,
+				Name:    "staleness/uncommitted",
 				Status:  StatusOK,
 				Message: "uncommitted changes are recent",
 			})
@@ -129,14 +114,14 @@ func worktreeStaleness(repo *Repo, wt string, maxUncommitted time.Duration) []Re
 	if len(untrackedLines) > 0 {
 		if stale {
 			results = append(results, Result{
-				Name:    "staleness/untracked" + suffix,
+				Name:    "staleness/untracked",
 				Status:  StatusFail,
 				Message: fmt.Sprintf("%d untracked files for %s (max %s)", len(untrackedLines), formatDuration(age), formatDuration(maxUncommitted)),
 				Details: untrackedLines,
 			})
 		} else {
 			results = append(results, Result{
-				Name:    "staleness/untracked" + suffix,
+				Name:    "staleness/untracked",
 				Status:  StatusOK,
 				Message: "untracked files are recent",
 			})
@@ -145,7 +130,7 @@ func worktreeStaleness(repo *Repo, wt string, maxUncommitted time.Duration) []Re
 
 	if len(uncommittedLines) == 0 && len(untrackedLines) == 0 {
 		results = append(results, Result{
-			Name:    "staleness/uncommitted" + suffix,
+			Name:    "staleness/uncommitted",
 			Status:  StatusOK,
 			Message: "working tree clean",
 		})
@@ -154,19 +139,52 @@ func worktreeStaleness(repo *Repo, wt string, maxUncommitted time.Duration) []Re
 	return results
 }
 
-// listWorktrees returns paths of all worktrees attached to the repo.
-func listWorktrees(repo *Repo) []string {
-	out, err := repo.Git("worktree", "list", "--porcelain")
-	if err != nil {
-		return nil
+// worktreeChanges returns the `git status --porcelain` lines of the worktree
+// at wt, split into changes to tracked files and untracked files. Untracked
+// files follow the untracked config: none with skip, and none matching an
+// ignore pattern.
+func worktreeChanges(repo *Repo, wt string) (uncommitted, untracked []string) {
+	untrackedMode := "--untracked-files=normal"
+	if repo.Config.Untracked.Skip {
+		untrackedMode = "--untracked-files=no"
 	}
-	var paths []string
-	for _, line := range strings.Split(out, "\n") {
-		if strings.HasPrefix(line, "worktree ") {
-			paths = append(paths, strings.TrimPrefix(line, "worktree "))
+	porcelain, _ := gitInDir(repo.Context(), wt, "status", "--porcelain", untrackedMode)
+	for _, line := range strings.Split(porcelain, "\n") {
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "?? ") {
+			if !pathMatchesAny(line[3:], repo.Config.Untracked.Ignore) {
+				untracked = append(untracked, line)
+			}
+		} else {
+			uncommitted = append(uncommitted, line)
+		}
+	}
+	return uncommitted, untracked
+}
+
+// pathMatchesAny reports whether the slash-separated path p matches one of
+// patterns: a glob matches the full path or the base name, and a pattern with
+// a trailing slash matches everything under that directory. Used for
+// untracked.ignore and executableFiles.
+func pathMatchesAny(p string, patterns []string) bool {
+	p = strings.TrimSuffix(p, "/")
+	for _, pattern := range patterns {
+		if dir, ok := strings.CutSuffix(pattern, "/"); ok {
+			if p == dir || strings.HasPrefix(p, dir+"/") {
+				return true
+			}
+			continue
+		}
+		if ok, _ := path.Match(pattern, p); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, path.Base(p)); ok {
+			return true
 		}
 	}
-	return paths
+	return false
 }
 
 func (c *StalenessCheck) Fix(_ *Repo, results []Result) []Result {
@@ -198,10 +216,23 @@ func stashEntries(repo *Repo) ([]stashEntry, error) {
 	return entries, nil
 }
 
-// uncommittedAge returns how long ago the working tree at dir last changed,
-// approximated by the time since its HEAD's last commit.
-func uncommittedAge(dir string) time.Duration {
-	out, err := gitInDir(dir, "log", "-1", "--format=%ci")
+// uncommittedAge returns how long ago the working tree at dir last changed:
+// the time since the newest modification among the files in porcelain, the
+// `git status --porcelain` lines for dir. Deleted files have nothing to stat;
+// when no file can be, the age falls back to the time since HEAD's last
+// commit.
+func uncommittedAge(ctx context.Context, dir string, porcelain []string) time.Duration {
+	var newest time.Time
+	for _, line := range porcelain {
+		info, err := os.Lstat(filepath.Join(dir, porcelainPath(line)))
+		if err == nil && info.ModTime().After(newest) {
+			newest = info.ModTime()
+		}
+	}
+	if !newest.IsZero() {
+		return time.Since(newest)
+	}
+	out, err := gitInDir(ctx, dir, "log", "-1", "--format=%ci")
 	if err != nil || out == "" {
 		return 0
 	}
@@ -212,6 +243,22 @@ func uncommittedAge(dir string) time.Duration {
 	return time.Since(t)
 }
 
+// porcelainPath returns the path of a `git status --porcelain` line, the new
+// name for a rename, with git's quoting removed.
+func porcelainPath(line string) string {
+	if len(line) < 4 {
+		return ""
+	}
+	p := line[3:]
+	if _, after, ok := strings.Cut(p, " -> "); ok {
+		p = after
+	}
+	if unquoted, err := strconv.Unquote(p); err == nil {
+		p = unquoted
+	}
+	return filepath.FromSlash(p)
+}
+
 func formatDuration(d time.Duration) string {
 	days := int(d.Hours()) / 24
 	if days > 0 {
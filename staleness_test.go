@@ -1,7 +1,9 @@
 package main
 
 import (
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 )
@@ -37,22 +39,109 @@ func TestStalenessCleanRepo(t *testing.T) {
 	}
 }
 
-func TestStalenessWorktreeSuffix(t *testing.T) {
+func TestStalenessSkipsLinkedWorktrees(t *testing.T) {
 	r := newTestRepo(t)
 	r.commit("file.txt", "hello", "initial", time.Now())
 
 	wtPath := filepath.Join(t.TempDir(), "linked")
 	r.git("worktree", "add", "-b", "feature", wtPath)
+	if err := os.WriteFile(filepath.Join(wtPath, "file.txt"), []byte("changed"), 0o644); err != nil {
+		t.Fatal(err)
+	}
 
+	// The dirty linked worktree is WorktreeCheck's to report; staleness
+	// only looks at the main worktree.
 	results := (&StalenessCheck{}).Check(r.Repo)
+	if got, _ := resultByName(results, "staleness/uncommitted"); got.Status != StatusOK {
+		t.Errorf("main worktree = %+v, want ok", got)
+	}
+	for _, res := range results {
+		if strings.Contains(res.Name, "[") {
+			t.Errorf("linked worktree reported by staleness: %+v", res)
+		}
+	}
+}
+
+func TestPathMatchesAny(t *testing.T) {
+	patterns := []string{"*.o", "build/", "dist/*.tar.gz"}
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"main.o", true},
+		{"src/lib.o", true},
+		{"build/", true},
+		{"build/out/x", true},
+		{"dist/app.tar.gz", true},
+		{"notes.txt", false},
+		{"builder/x", false},
+	}
+	for _, tt := range tests {
+		if got := pathMatchesAny(tt.path, patterns); got != tt.want {
+			t.Errorf("pathMatchesAny(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestStalenessUntrackedConfig(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("file.txt", "hello", "initial", time.Now())
+	if err := os.WriteFile(filepath.Join(r.dir, "artifact.o"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := resultByName((&StalenessCheck{}).Check(r.Repo), "staleness/untracked"); !ok {
+		t.Fatal("untracked file should be reported by default")
+	}
+
+	r.Config.Untracked.Ignore = []string{"*.o"}
+	if _, ok := resultByName((&StalenessCheck{}).Check(r.Repo), "staleness/untracked"); ok {
+		t.Error("ignored pattern should not be reported")
+	}
+
+	r.Config.Untracked = UntrackedConfig{Skip: true}
+	if _, ok := resultByName((&StalenessCheck{}).Check(r.Repo), "staleness/untracked"); ok {
+		t.Error("skip should drop untracked reporting")
+	}
+}
+
+func TestStalenessUncommittedAgeFromMtime(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("file.txt", "hello", "initial", time.Now())
+	r.Config.Thresholds.UncommittedMaxAge = Duration{24 * time.Hour}
+	path := filepath.Join(r.dir, "file.txt")
+	if err := os.WriteFile(path, []byte("changed"), 0o644); err != nil {
+		t.Fatal(err)
+	}
 
-	// The main worktree's result carries no suffix; the linked worktree's
-	// does. This exercises the path comparison that differs across platforms.
-	if _, ok := resultByName(results, "staleness/uncommitted"); !ok {
-		var names []string
-		for _, res := range results {
-			names = append(names, res.Name)
+	// A fresh commit does not hide changes that have sat for a month.
+	old := time.Now().Add(-30 * 24 * time.Hour)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := resultByName((&StalenessCheck{}).Check(r.Repo), "staleness/uncommitted"); got.Status != StatusFail {
+		t.Errorf("month-old change = %+v, want fail", got)
+	}
+
+	now := time.Now()
+	if err := os.Chtimes(path, now, now); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := resultByName((&StalenessCheck{}).Check(r.Repo), "staleness/uncommitted"); got.Status != StatusOK {
+		t.Errorf("fresh change = %+v, want ok", got)
+	}
+}
+
+func TestPorcelainPath(t *testing.T) {
+	tests := map[string]string{
+		" M file.txt":         "file.txt",
+		"?? dir/":             "dir/",
+		"R  old.go -> new.go": "new.go",
+		`?? "sp ace\tx"`:      "sp ace\tx",
+	}
+	for line, want := range tests {
+		if got := porcelainPath(line); got != filepath.FromSlash(want) {
+			t.Errorf("porcelainPath(%q) = %q, want %q", line, got, want)
 		}
-		t.Errorf("main worktree result missing unsuffixed name; got names %v", names)
 	}
 }
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// resultState maps repo directory to result name to when git-lint first
+// reported that result as a warning or failure.
+type resultState map[string]map[string]stateEntry
+
+// stateEntry is one remembered finding and the check that reported it.
+type stateEntry struct {
+	FirstSeen time.Time `json:"first_seen"`
+	Check     string    `json:"check,omitempty"`
+}
+
+// UnmarshalJSON also accepts the bare timestamp earlier versions wrote,
+// which leaves Check empty.
+func (e *stateEntry) UnmarshalJSON(data []byte) error {
+	var t time.Time
+	if err := json.Unmarshal(data, &t); err == nil {
+		*e = stateEntry{FirstSeen: t}
+		return nil
+	}
+	type plain stateEntry
+	return json.Unmarshal(data, (*plain)(e))
+}
+
+func statePath() string {
+	if dir := os.Getenv(configDirEnv); dir != "" {
+		return filepath.Join(dir, "state.json")
+	}
+	if dir := os.Getenv("XDG_STATE_HOME"); dir != "" {
+		return filepath.Join(dir, "git-lint", "state.json")
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".local", "state", "git-lint", "state.json")
+}
+
+func loadState(path string) (resultState, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return resultState{}, nil
+		}
+		return nil, fmt.Errorf("reading state %s: %w", path, err)
+	}
+	state := resultState{}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("parsing state %s: %w", path, err)
+	}
+	return state, nil
+}
+
+func (s resultState) save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// annotate sets FirstSeen on every warning or failure in results, recording
+// now for results not seen before. Entries that are no longer reported are
+// dropped, so a finding that comes back later starts fresh, but only when
+// their check is in ran: a run limited by --only, --skip, or --staged keeps
+// the history of the checks it left out. Entries from earlier versions,
+// which don't know their check, are dropped whenever they are not reported.
+func (s resultState) annotate(dir string, results []Result, ran map[string]bool, now time.Time) {
+	seen := s[dir]
+	current := map[string]stateEntry{}
+	for name, e := range seen {
+		if e.Check != "" && !ran[e.Check] {
+			current[name] = e
+		}
+	}
+	for i := range results {
+		r := &results[i]
+		if r.Status != StatusWarn && r.Status != StatusFail {
+			continue
+		}
+		first := now.UTC().Truncate(time.Second)
+		if e, ok := seen[r.Name]; ok {
+			first = e.FirstSeen
+		}
+		current[r.Name] = stateEntry{FirstSeen: first, Check: r.Check}
+		r.FirstSeen = &first
+	}
+	if len(current) == 0 {
+		delete(s, dir)
+		return
+	}
+	s[dir] = current
+}
+
+// recordFirstSeen loads the state file, annotates results for dir from the
+// checks in ran, and writes the state back. Errors are reported but don't
+// fail the run.
+func recordFirstSeen(dir string, results []Result, ran map[string]bool) {
+	path := statePath()
+	state, err := loadState(path)
+	if err == nil {
+		state.annotate(dir, results, ran, time.Now())
+		err = state.save(path)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestResultStateAnnotate(t *testing.T) {
+	state := resultState{}
+	day1 := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	day2 := day1.Add(24 * time.Hour)
+
+	results := []Result{
+		{Name: "staleness/stash", Status: StatusWarn},
+		{Name: "identity/email", Status: StatusOK},
+	}
+	state.annotate("/repo", results, nil, day1)
+	if results[0].FirstSeen == nil || !results[0].FirstSeen.Equal(day1) {
+		t.Errorf("new warning first_seen = %v, want %v", results[0].FirstSeen, day1)
+	}
+	if results[1].FirstSeen != nil {
+		t.Errorf("ok result first_seen = %v, want nil", results[1].FirstSeen)
+	}
+
+	// A finding that persists keeps its original timestamp; a new one gets now.
+	results = []Result{
+		{Name: "staleness/stash", Status: StatusWarn},
+		{Name: "branch/merged[old]", Status: StatusWarn},
+	}
+	state.annotate("/repo", results, nil, day2)
+	if !results[0].FirstSeen.Equal(day1) {
+		t.Errorf("persisting first_seen = %v, want %v", results[0].FirstSeen, day1)
+	}
+	if !results[1].FirstSeen.Equal(day2) {
+		t.Errorf("new first_seen = %v, want %v", results[1].FirstSeen, day2)
+	}
+
+	// Once resolved, the entry is dropped and a recurrence starts fresh.
+	state.annotate("/repo", nil, nil, day2)
+	if _, ok := state["/repo"]; ok {
+		t.Errorf("state after resolution = %v, want repo entry removed", state)
+	}
+}
+
+func TestResultStateRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "git-lint", "state.json")
+	state, err := loadState(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	when := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	state.annotate("/repo", []Result{{Name: "tags/untagged", Status: StatusWarn, Check: "tags"}}, map[string]bool{"tags": true}, when)
+	if err := state.save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := loadState(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := loaded["/repo"]["tags/untagged"]; !got.FirstSeen.Equal(when) || got.Check != "tags" {
+		t.Errorf("loaded entry = %+v, want first_seen %v from tags", got, when)
+	}
+}
+
+func TestResultStateKeepsChecksThatDidNotRun(t *testing.T) {
+	state := resultState{}
+	day1 := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	day2 := day1.Add(24 * time.Hour)
+	all := map[string]bool{"staleness": true, "tags": true}
+	state.annotate("/repo", []Result{
+		{Name: "staleness/stash-age", Status: StatusWarn, Check: "staleness"},
+		{Name: "tags/untagged", Status: StatusWarn, Check: "tags"},
+	}, all, day1)
+
+	// --only staleness: the tags finding was not looked at, so it stays.
+	results := []Result{{Name: "staleness/stash-age", Status: StatusWarn, Check: "staleness"}}
+	state.annotate("/repo", results, map[string]bool{"staleness": true}, day2)
+	if got, ok := state["/repo"]["tags/untagged"]; !ok || !got.FirstSeen.Equal(day1) {
+		t.Errorf("tags entry after a staleness-only run = %+v, %v; want kept from %v", got, ok, day1)
+	}
+
+	// A full run that no longer reports it drops it.
+	state.annotate("/repo", results, all, day2)
+	if _, ok := state["/repo"]["tags/untagged"]; ok {
+		t.Error("resolved tags entry kept after a full run")
+	}
+}
+
+func TestResultStateReadsBareTimestamps(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	if err := os.WriteFile(path, []byte(`{"/repo": {"tags/untagged": "2024-03-01T12:00:00Z"}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	state, err := loadState(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	if got := state["/repo"]["tags/untagged"]; !got.FirstSeen.Equal(want) || got.Check != "" {
+		t.Errorf("entry = %+v, want first_seen %v and no check", got, want)
+	}
+}
@@ -1,19 +1,24 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 )
 
 type SubmoduleCheck struct{}
 
+func (c *SubmoduleCheck) Name() string { return "submodules" }
+
 func (c *SubmoduleCheck) Check(repo *Repo) []Result {
 	if _, err := os.Stat(filepath.Join(repo.Dir, ".gitmodules")); err != nil {
 		return nil
 	}
+	if repo.Ref != "" {
+		return refNotApplicable("submodule/status")
+	}
 
 	paths, prefixes, err := submoduleStatus(repo)
 	if err != nil {
@@ -61,7 +66,7 @@ func (c *SubmoduleCheck) checkSubmodule(repo *Repo, path string, prefix byte) []
 
 	// Uncommitted and untracked: run git status inside the submodule.
 	absPath := filepath.Join(repo.Dir, path)
-	porcelain, err := gitInDir(absPath, "status", "--porcelain")
+	porcelain, err := gitInDir(repo.Context(), absPath, "status", "--porcelain")
 	if err == nil && porcelain != "" {
 		var uncommittedDetails, untrackedDetails []string
 		for _, line := range strings.Split(porcelain, "\n") {
@@ -90,7 +95,7 @@ func (c *SubmoduleCheck) checkSubmodule(repo *Repo, path string, prefix byte) []
 	}
 
 	// Unpushed: commits ahead of upstream. Skip if no upstream configured.
-	unpushed, err := gitInDir(absPath, "log", "@{upstream}..HEAD", "--oneline")
+	unpushed, err := gitInDir(repo.Context(), absPath, "log", "@{upstream}..HEAD", "--oneline")
 	if err == nil && unpushed != "" {
 		lines := strings.Split(unpushed, "\n")
 		results = append(results, Result{
@@ -144,36 +149,42 @@ func (c *SubmoduleCheck) Fix(repo *Repo, results []Result) []Result {
 }
 
 // submoduleStatus parses `git submodule status` into paths and prefix characters.
-// Each line has format: <prefix><sha> <path> [(<describe>)]
 func submoduleStatus(repo *Repo) (paths []string, prefixes []byte, err error) {
 	out, err := repo.Git("submodule", "status")
 	if err != nil {
 		return nil, nil, err
 	}
-	if out == "" {
-		return nil, nil, nil
-	}
+	paths, prefixes = parseSubmoduleStatus(out)
+	return paths, prefixes, nil
+}
+
+// parseSubmoduleStatus splits `git submodule status` lines by position,
+// since paths may contain spaces: a prefix character (' ', '-', '+', or
+// 'U'), the hex commit, one space, then the path, followed by " (describe)"
+// for checked-out submodules.
+func parseSubmoduleStatus(out string) (paths []string, prefixes []byte) {
 	for _, line := range strings.Split(out, "\n") {
 		if len(line) < 2 {
 			continue
 		}
 		prefix := line[0]
-		// After the prefix+sha, the path is the next space-delimited field.
-		rest := line[1:] // skip prefix
-		fields := strings.Fields(rest)
-		if len(fields) < 2 {
+		_, path, ok := strings.Cut(line[1:], " ")
+		if !ok || path == "" {
 			continue
 		}
-		paths = append(paths, fields[1])
+		// Uninitialized submodules have nothing to describe.
+		if prefix != '-' && strings.HasSuffix(path, ")") {
+			if i := strings.LastIndex(path, " ("); i > 0 {
+				path = path[:i]
+			}
+		}
+		paths = append(paths, path)
 		prefixes = append(prefixes, prefix)
 	}
-	return paths, prefixes, nil
+	return paths, prefixes
 }
 
 // gitInDir runs a git command in the given directory and returns trimmed stdout.
-func gitInDir(dir string, args ...string) (string, error) {
-	cmd := exec.Command("git", args...)
-	cmd.Dir = dir
-	out, err := cmd.Output()
-	return strings.TrimRight(string(out), "\n"), err
+func gitInDir(ctx context.Context, dir string, args ...string) (string, error) {
+	return runCommand(ctx, dir, "git", args...)
 }
@@ -3,6 +3,8 @@ package main
 import (
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 )
@@ -50,3 +52,38 @@ func TestSubmoduleUntrackedFiles(t *testing.T) {
 		t.Fatalf("submodule untracked = %+v, want warn", results)
 	}
 }
+
+func TestParseSubmoduleStatus(t *testing.T) {
+	sha := strings.Repeat("a", 40)
+	out := " " + sha + " lib/my lib (heads/main)\n" +
+		"-" + sha + " vendor/not yet\n" +
+		"+" + sha + " plain (v1.0-2-gabcdef0)\n" +
+		"U" + sha + " conflicted"
+	paths, prefixes := parseSubmoduleStatus(out)
+	wantPaths := []string{"lib/my lib", "vendor/not yet", "plain", "conflicted"}
+	if !slices.Equal(paths, wantPaths) {
+		t.Errorf("paths = %q, want %q", paths, wantPaths)
+	}
+	if string(prefixes) != " -+U" {
+		t.Errorf("prefixes = %q, want \" -+U\"", prefixes)
+	}
+}
+
+func TestSubmoduleStatusSpacedPath(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("a.txt", "a", "first", time.Now())
+	src := t.TempDir()
+	runGit(t, src, nil, "init", "--initial-branch=main")
+	runGit(t, src, nil, "config", "user.name", "Test User")
+	runGit(t, src, nil, "config", "user.email", "test@example.com")
+	runGit(t, src, nil, "commit", "--allow-empty", "--message", "lib")
+	r.git("-c", "protocol.file.allow=always", "submodule", "add", src, "my lib")
+
+	paths, prefixes, err := submoduleStatus(r.Repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) != 1 || paths[0] != "my lib" || prefixes[0] != ' ' {
+		t.Errorf("submoduleStatus = %q %q, want [\"my lib\"] with prefix ' '", paths, prefixes)
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// repoSummary counts checked repos by their worst result. The probe, the
+// JSON reports, and the text summary at the end of a recursive run all build
+// one, so they agree on what counts as a warned or failed repo.
+type repoSummary struct {
+	ReposChecked int      `json:"repos_checked"`
+	ReposOK      int      `json:"repos_ok"`
+	ReposWarned  int      `json:"repos_warned"`
+	ReposFailed  int      `json:"repos_failed"`
+	WorstStatus  string   `json:"worst_status"`
+	Failed       []string `json:"failed,omitempty"` // names of the failed repos, in scan order
+}
+
+func newRepoSummary() repoSummary {
+	return repoSummary{WorstStatus: "ok"}
+}
+
+// add counts a repo's results and returns its status.
+func (s *repoSummary) add(name string, results []Result) string {
+	status := classifyResults(results)
+	s.ReposChecked++
+	switch status {
+	case "critical":
+		s.ReposFailed++
+		s.Failed = append(s.Failed, name)
+		s.WorstStatus = "critical"
+	case "warning":
+		s.ReposWarned++
+		if s.WorstStatus == "ok" {
+			s.WorstStatus = "warning"
+		}
+	default:
+		s.ReposOK++
+	}
+	return status
+}
+
+// print writes the summary section for text output.
+func (s *repoSummary) print(w io.Writer) {
+	fmt.Fprintf(w, "\n=== summary ===\n")
+	fmt.Fprintf(w, "%d repos: %d ok, %d warned, %d failed\n",
+		s.ReposChecked, s.ReposOK, s.ReposWarned, s.ReposFailed)
+	if len(s.Failed) > 0 {
+		fmt.Fprintf(w, "failed: %s\n", strings.Join(s.Failed, ", "))
+	}
+}
+
+// classifyResults maps git-lint result statuses to probe statuses.
+// fail → critical, warn → warning, ok/fix → ok.
+// Returns the worst status across all results.
+func classifyResults(results []Result) string {
+	worst := "ok"
+	for _, r := range results {
+		switch r.Status {
+		case StatusFail:
+			return "critical"
+		case StatusWarn:
+			worst = "warning"
+		}
+	}
+	return worst
+}
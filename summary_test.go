@@ -0,0 +1,40 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestClassifyResults(t *testing.T) {
+	tests := []struct {
+		name    string
+		results []Result
+		want    string
+	}{
+		{"empty", nil, "ok"},
+		{"all ok", []Result{{Status: StatusOK}}, "ok"},
+		{"warn", []Result{{Status: StatusOK}, {Status: StatusWarn}}, "warning"},
+		{"fail", []Result{{Status: StatusWarn}, {Status: StatusFail}}, "critical"},
+	}
+	for _, tt := range tests {
+		if got := classifyResults(tt.results); got != tt.want {
+			t.Errorf("classifyResults(%s) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRepoSummaryPrint(t *testing.T) {
+	s := newRepoSummary()
+	s.add("clean", []Result{{Status: StatusOK}})
+	s.add("fixed", []Result{{Status: StatusFix}})
+	s.add("stale", []Result{{Status: StatusWarn}})
+	s.add("broken", []Result{{Status: StatusFail}})
+	s.add("worse", []Result{{Status: StatusWarn}, {Status: StatusFail}})
+
+	var buf bytes.Buffer
+	s.print(&buf)
+	want := "\n=== summary ===\n5 repos: 2 ok, 1 warned, 2 failed\nfailed: broken, worse\n"
+	if buf.String() != want {
+		t.Errorf("summary = %q, want %q", buf.String(), want)
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// SuperprojectCheck warns when a repo's git dir lives under another repo's
+// .git/modules, marking it as a submodule, but git finds no superproject
+// that records it. The parent's `git submodule` commands no longer manage
+// such a detached submodule.
+type SuperprojectCheck struct{}
+
+func (c *SuperprojectCheck) Name() string { return "superproject" }
+
+func (c *SuperprojectCheck) Check(repo *Repo) []Result {
+	gitDir, err := repo.Git("rev-parse", "--absolute-git-dir")
+	if err != nil {
+		return nil
+	}
+	super, ok := expectedSuperproject(gitDir)
+	if !ok {
+		return nil
+	}
+	if linked, _ := repo.Git("rev-parse", "--show-superproject-working-tree"); linked != "" {
+		return nil
+	}
+	return []Result{{
+		Name:    "submodule/superproject",
+		Status:  StatusWarn,
+		Message: "submodule is not linked from its superproject",
+		Details: []string{fmt.Sprintf("expected superproject: %s", super)},
+	}}
+}
+
+func (c *SuperprojectCheck) Fix(_ *Repo, results []Result) []Result {
+	return results
+}
+
+// expectedSuperproject returns the work tree of the repo whose
+// .git/modules directory contains gitDir, or false if gitDir isn't a
+// submodule git dir.
+func expectedSuperproject(gitDir string) (string, bool) {
+	marker := string(filepath.Separator) + filepath.Join(".git", "modules") + string(filepath.Separator)
+	i := strings.Index(gitDir, marker)
+	if i < 0 {
+		return "", false
+	}
+	return gitDir[:i], true
+}
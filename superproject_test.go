@@ -0,0 +1,51 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestExpectedSuperproject(t *testing.T) {
+	if got, ok := expectedSuperproject("/src/app/.git/modules/lib"); !ok || got != "/src/app" {
+		t.Errorf("submodule git dir = %q, %v; want /src/app", got, ok)
+	}
+	if _, ok := expectedSuperproject("/src/app/.git"); ok {
+		t.Error("plain git dir reported as submodule")
+	}
+}
+
+func TestSuperprojectCheckDetachedSubmodule(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("a.txt", "a", "first", time.Now())
+
+	src, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, src, nil, "init", "--initial-branch=main")
+	if err := os.WriteFile(filepath.Join(src, "lib.txt"), []byte("lib"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, src, nil, "add", "lib.txt")
+	runGit(t, src, []string{"GIT_AUTHOR_NAME=Test User", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=Test User", "GIT_COMMITTER_EMAIL=test@example.com"},
+		"commit", "--message", "lib")
+	r.git("-c", "protocol.file.allow=always", "submodule", "add", src, "sub")
+
+	sub, err := NewRepo(filepath.Join(r.dir, "sub"), r.Config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results := (&SuperprojectCheck{}).Check(sub); len(results) != 0 {
+		t.Fatalf("linked submodule: got %+v, want none", results)
+	}
+
+	// Dropping the gitlink from the parent's index detaches the submodule.
+	r.git("rm", "--cached", "sub")
+	got, ok := resultByName((&SuperprojectCheck{}).Check(sub), "submodule/superproject")
+	if !ok || got.Status != StatusWarn || len(got.Details) != 1 {
+		t.Fatalf("submodule/superproject = %+v, want warn with expected path", got)
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// SymlinkCheck warns about tracked symlinks whose targets are absolute,
+// escape the repo root, or do not exist. Such links break on other machines,
+// and with core.symlinks=false (the Windows default) they check out as plain
+// files containing the target path.
+type SymlinkCheck struct{}
+
+func (c *SymlinkCheck) Name() string { return "symlinks" }
+
+func (c *SymlinkCheck) Check(repo *Repo) []Result {
+	// Under --ref, read the ref's tree and test targets against it rather
+	// than the working tree.
+	var out string
+	var err error
+	exists := func(p string) bool {
+		_, err := os.Lstat(filepath.Join(repo.Dir, filepath.FromSlash(p)))
+		return err == nil
+	}
+	if repo.Ref != "" {
+		out, err = repo.Git("ls-tree", "-r", repo.Ref)
+		exists = func(p string) bool {
+			_, err := repo.Git("cat-file", "-e", repo.Ref+":"+p)
+			return err == nil
+		}
+	} else {
+		out, err = repo.Git("ls-files", "-s")
+	}
+	if err != nil || out == "" {
+		return nil
+	}
+
+	var details []string
+	found := false
+	for _, line := range strings.Split(out, "\n") {
+		// Format: <mode> <object> <stage>\t<path> (ls-files) or
+		// <mode> <type> <object>\t<path> (ls-tree).
+		meta, file, ok := strings.Cut(line, "\t")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(meta)
+		if len(fields) < 3 || fields[0] != "120000" {
+			continue
+		}
+		found = true
+		object := fields[1]
+		if repo.Ref != "" {
+			object = fields[2]
+		}
+		target, err := repo.Git("cat-file", "blob", object)
+		if err != nil {
+			continue
+		}
+		if reason := symlinkProblem(file, target, exists); reason != "" {
+			details = append(details, fmt.Sprintf("%s -> %s (%s)", file, target, reason))
+		}
+	}
+	if !found {
+		return nil
+	}
+
+	if len(details) > 0 {
+		return []Result{{
+			Name:    "files/symlinks",
+			Status:  StatusWarn,
+			Message: fmt.Sprintf("%d tracked symlinks are absolute, escape the repo, or dangle", len(details)),
+			Details: details,
+		}}
+	}
+	return []Result{{
+		Name:    "files/symlinks",
+		Status:  StatusOK,
+		Message: "tracked symlinks resolve inside the repo",
+	}}
+}
+
+func (c *SymlinkCheck) Fix(_ *Repo, results []Result) []Result {
+	return results
+}
+
+// symlinkProblem describes what is wrong with a tracked symlink at file (a
+// slash-separated path relative to the repo root) pointing at target, or
+// returns "" if the target is relative, stays inside the repo, and exists
+// according to exists.
+func symlinkProblem(file, target string, exists func(string) bool) string {
+	if path.IsAbs(target) || filepath.IsAbs(target) {
+		return "absolute"
+	}
+	resolved := path.Clean(path.Join(path.Dir(file), target))
+	if resolved == ".." || strings.HasPrefix(resolved, "../") {
+		return "outside repo"
+	}
+	if !exists(resolved) {
+		return "missing target"
+	}
+	return ""
+}
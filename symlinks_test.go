@@ -0,0 +1,77 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSymlinkProblem(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "docs"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "README.md"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		file, target, want string
+	}{
+		{"docs/readme", "../README.md", ""},
+		{"link", "README.md", ""},
+		{"link", "/etc/passwd", "absolute"},
+		{"docs/up", "../../outside", "outside repo"},
+		{"link", "..", "outside repo"},
+		{"docs/gone", "missing.md", "missing target"},
+	}
+	exists := func(p string) bool {
+		_, err := os.Lstat(filepath.Join(root, filepath.FromSlash(p)))
+		return err == nil
+	}
+	for _, tt := range tests {
+		if got := symlinkProblem(tt.file, tt.target, exists); got != tt.want {
+			t.Errorf("symlinkProblem(%q, %q) = %q, want %q", tt.file, tt.target, got, tt.want)
+		}
+	}
+}
+
+func TestSymlinkCheckReadsTrackedLinks(t *testing.T) {
+	r := newTestRepo(t)
+	// Stage symlinks straight into the index so the test does not depend on
+	// the platform's ability to create them.
+	targetFile := filepath.Join(t.TempDir(), "target")
+	if err := os.WriteFile(targetFile, []byte("nowhere"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	blob := r.git("hash-object", "-w", targetFile)
+	r.git("update-index", "--add", "--cacheinfo", "120000,"+blob+",broken")
+
+	got, ok := resultByName((&SymlinkCheck{}).Check(r.Repo), "files/symlinks")
+	if !ok || got.Status != StatusWarn || len(got.Details) != 1 {
+		t.Fatalf("files/symlinks = %+v, want warn with one detail", got)
+	}
+}
+
+func TestSymlinkCheckReadsRefTree(t *testing.T) {
+	r := newTestRepo(t)
+	targetFile := filepath.Join(t.TempDir(), "target")
+	if err := os.WriteFile(targetFile, []byte("README.md"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	blob := r.git("hash-object", "-w", targetFile)
+	r.git("update-index", "--add", "--cacheinfo", "120000,"+blob+",link")
+	r.git("commit", "--message", "add link")
+
+	// README.md exists in the working tree but not in the committed tree.
+	if err := os.WriteFile(filepath.Join(r.dir, "README.md"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := resultByName((&SymlinkCheck{}).Check(r.Repo), "files/symlinks"); got.Status != StatusOK {
+		t.Errorf("working tree: files/symlinks = %+v, want ok", got)
+	}
+	r.Repo.Ref = "HEAD"
+	if got, _ := resultByName((&SymlinkCheck{}).Check(r.Repo), "files/symlinks"); got.Status != StatusWarn {
+		t.Errorf("--ref HEAD: files/symlinks = %+v, want warn", got)
+	}
+}
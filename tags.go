@@ -0,0 +1,181 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TagCheck covers release tags, with three opt-in rules. With
+// thresholds.untaggedMaxCommits set it warns when the main branch has
+// accumulated many commits while the repo has no tags at all. With online
+// set it lists local tags that origin does not have, since git push does not
+// push tags by default. With thresholds.tagMaxAge set it lists old tags
+// whose commit is no longer on any branch, e.g. after a rebase or a deleted
+// release branch.
+type TagCheck struct{}
+
+func (c *TagCheck) Name() string { return "tags" }
+
+func (c *TagCheck) Check(repo *Repo) []Result {
+	var results []Result
+	results = append(results, untaggedResults(repo)...)
+	results = append(results, unpushedTagResults(repo)...)
+	results = append(results, unreachableTagResults(repo, time.Now())...)
+	return results
+}
+
+func (c *TagCheck) Fix(_ *Repo, results []Result) []Result {
+	return results
+}
+
+// untaggedResults warns when main has more than untaggedMaxCommits commits
+// and the repo has no tags.
+func untaggedResults(repo *Repo) []Result {
+	maxCommits := repo.Config.Thresholds.UntaggedMaxCommits
+	if maxCommits == 0 {
+		return nil
+	}
+	mainBranch := repo.MainBranch()
+	if mainBranch == "" {
+		return nil
+	}
+
+	tags, err := repo.Git("tag", "--list")
+	if err != nil {
+		return nil
+	}
+	if tags != "" {
+		return []Result{{
+			Name:    "tags/untagged",
+			Status:  StatusOK,
+			Message: "repo has tags",
+		}}
+	}
+
+	out, err := repo.Git("rev-list", "--count", mainBranch)
+	if err != nil {
+		return nil
+	}
+	count, err := strconv.Atoi(out)
+	if err != nil {
+		return nil
+	}
+	if count > maxCommits {
+		return []Result{{
+			Name:    "tags/untagged",
+			Status:  StatusWarn,
+			Message: fmt.Sprintf("%d commits on %s and no tags (max %d); consider tagging a release", count, mainBranch, maxCommits),
+		}}
+	}
+	return []Result{{
+		Name:    "tags/untagged",
+		Status:  StatusOK,
+		Message: fmt.Sprintf("%d commits on %s", count, mainBranch),
+	}}
+}
+
+// unpushedTagResults warns about local tags missing on origin. It asks
+// origin with git ls-remote, so it only runs when online is set.
+func unpushedTagResults(repo *Repo) []Result {
+	if !repo.Config.Online || !repo.hasRemoteNamed("origin") {
+		return nil
+	}
+	local, err := repo.Git("tag", "--list")
+	if err != nil || local == "" {
+		return nil
+	}
+	out, err := repo.Git("ls-remote", "--tags", "origin")
+	if err != nil {
+		return nil
+	}
+	remote := map[string]bool{}
+	for _, line := range strings.Split(out, "\n") {
+		if _, ref, ok := strings.Cut(line, "\t"); ok {
+			remote[strings.TrimSuffix(strings.TrimPrefix(ref, "refs/tags/"), "^{}")] = true
+		}
+	}
+
+	var missing []string
+	for _, tag := range strings.Split(local, "\n") {
+		if !remote[tag] {
+			missing = append(missing, tag)
+		}
+	}
+	if len(missing) > 0 {
+		return []Result{{
+			Name:    "tags/unpushed",
+			Status:  StatusWarn,
+			Message: fmt.Sprintf("%d tags not on origin (push them with git push origin --tags)", len(missing)),
+			Details: missing,
+		}}
+	}
+	return []Result{{
+		Name:    "tags/unpushed",
+		Status:  StatusOK,
+		Message: "all tags are on origin",
+	}}
+}
+
+// unreachableTagResults warns about tags older than tagMaxAge whose commit
+// is not reachable from any local or remote-tracking branch.
+func unreachableTagResults(repo *Repo, now time.Time) []Result {
+	maxAge := repo.Config.Thresholds.TagMaxAge.Duration
+	if maxAge == 0 {
+		return nil
+	}
+	// Commits only tags reach; usually none.
+	out, err := repo.Git("rev-list", "--tags", "--not", "--branches", "--remotes")
+	if err != nil {
+		return nil
+	}
+	if out == "" {
+		return []Result{{
+			Name:    "tags/unreachable",
+			Status:  StatusOK,
+			Message: "all tags are on a branch",
+		}}
+	}
+	orphaned := map[string]bool{}
+	for _, sha := range strings.Split(out, "\n") {
+		orphaned[sha] = true
+	}
+
+	refs, err := repo.Git("for-each-ref", "refs/tags", "--format=%(refname:short)%00%(objectname)%00%(*objectname)%00%(creatordate:unix)")
+	if err != nil {
+		return nil
+	}
+	var details []string
+	for _, line := range strings.Split(refs, "\n") {
+		f := strings.Split(line, "\x00")
+		if len(f) != 4 {
+			continue
+		}
+		name, commit := f[0], f[1]
+		if f[2] != "" {
+			// Annotated tag: the commit is the peeled object.
+			commit = f[2]
+		}
+		created, err := strconv.ParseInt(f[3], 10, 64)
+		if err != nil || !orphaned[commit] {
+			continue
+		}
+		if age := now.Sub(time.Unix(created, 0)); age > maxAge {
+			details = append(details, fmt.Sprintf("%s (%s old)", name, formatDuration(age)))
+		}
+	}
+	if len(details) > 0 {
+		return []Result{{
+			Name:    "tags/unreachable",
+			Status:  StatusWarn,
+			Message: fmt.Sprintf("%d tags older than %s point at commits on no branch", len(details), formatDuration(maxAge)),
+			Details: details,
+		}}
+	}
+	return []Result{{
+		Name:    "tags/unreachable",
+		Status:  StatusOK,
+		Message: "old tags are all on a branch",
+	}}
+}
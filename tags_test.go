@@ -0,0 +1,84 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTagCheckDisabledByDefault(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("a.txt", "a", "first", time.Now())
+	if results := (&TagCheck{}).Check(r.Repo); len(results) != 0 {
+		t.Errorf("got %+v, want none when threshold is unset", results)
+	}
+}
+
+func TestTagCheckUntagged(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("a.txt", "a", "first", time.Now())
+	r.commit("b.txt", "b", "second", time.Now())
+	r.commit("c.txt", "c", "third", time.Now())
+	r.Config.Thresholds.UntaggedMaxCommits = 2
+
+	got, ok := resultByName((&TagCheck{}).Check(r.Repo), "tags/untagged")
+	if !ok || got.Status != StatusWarn {
+		t.Fatalf("tags/untagged = %+v, want warn", got)
+	}
+
+	r.git("tag", "v0.1.0")
+	got, _ = resultByName((&TagCheck{}).Check(r.Repo), "tags/untagged")
+	if got.Status != StatusOK {
+		t.Errorf("after tagging: status = %q (%q), want ok", got.Status, got.Message)
+	}
+}
+
+func TestTagCheckUnpushed(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("a.txt", "a", "first", time.Now())
+	origin := t.TempDir()
+	runGit(t, origin, nil, "init", "--bare")
+	r.git("remote", "add", "origin", origin)
+	r.git("tag", "v1.0.0")
+	r.git("tag", "--annotate", "--message", "release", "v1.1.0")
+	r.git("push", "origin", "main", "v1.0.0")
+	r.Config.Online = true
+
+	got, ok := resultByName((&TagCheck{}).Check(r.Repo), "tags/unpushed")
+	if !ok || got.Status != StatusWarn || len(got.Details) != 1 || got.Details[0] != "v1.1.0" {
+		t.Fatalf("tags/unpushed = %+v, want warn listing v1.1.0", got)
+	}
+
+	r.git("push", "origin", "--tags")
+	got, _ = resultByName((&TagCheck{}).Check(r.Repo), "tags/unpushed")
+	if got.Status != StatusOK {
+		t.Errorf("after push: %+v, want ok", got)
+	}
+}
+
+func TestTagCheckUnreachable(t *testing.T) {
+	r := newTestRepo(t)
+	old := time.Now().Add(-60 * 24 * time.Hour)
+	r.commit("a.txt", "a", "first", old)
+	r.git("tag", "v1.0.0")
+	r.git("checkout", "--quiet", "-b", "release")
+	r.commit("b.txt", "b", "release fix", old)
+	r.git("tag", "v1.0.1")
+	r.git("checkout", "--quiet", "main")
+	r.Config.Thresholds.TagMaxAge = Duration{30 * 24 * time.Hour}
+
+	if got, _ := resultByName((&TagCheck{}).Check(r.Repo), "tags/unreachable"); got.Status != StatusOK {
+		t.Fatalf("tags on branches: %+v, want ok", got)
+	}
+
+	r.git("branch", "-D", "release")
+	got, ok := resultByName((&TagCheck{}).Check(r.Repo), "tags/unreachable")
+	if !ok || got.Status != StatusWarn || len(got.Details) != 1 || !strings.HasPrefix(got.Details[0], "v1.0.1 ") {
+		t.Fatalf("tags/unreachable = %+v, want warn listing v1.0.1", got)
+	}
+
+	r.Config.Thresholds.TagMaxAge = Duration{90 * 24 * time.Hour}
+	if got, _ := resultByName((&TagCheck{}).Check(r.Repo), "tags/unreachable"); got.Status != StatusOK {
+		t.Errorf("tag younger than tagMaxAge: %+v, want ok", got)
+	}
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// writeTSV renders the report as tab-separated values for pasting into a
+// spreadsheet: a header row, then one row per result that isn't ok with the
+// repo, result name, status, whether --fix could resolve it, and the message.
+// Tabs and newlines in the message become spaces so every result stays on one
+// row.
+func (rep *jsonReport) writeTSV(w io.Writer) error {
+	if _, err := fmt.Fprintln(w, "repo\tcheck\tstatus\tfixable\tmessage"); err != nil {
+		return err
+	}
+	for _, repo := range rep.Repos {
+		for _, r := range repo.Results {
+			if r.Status == StatusOK {
+				continue
+			}
+			row := []string{repo.Name, r.Name, r.Status, strconv.FormatBool(r.Fixable), r.Message}
+			for i, field := range row {
+				row[i] = tsvField(field)
+			}
+			if _, err := fmt.Fprintln(w, strings.Join(row, "\t")); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// tsvField replaces the characters that would break a TSV row with spaces.
+func tsvField(s string) string {
+	return strings.NewReplacer("\t", " ", "\r\n", " ", "\n", " ", "\r", " ").Replace(s)
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestTSVReport(t *testing.T) {
+	rep := newJSONReport()
+	rep.add("clean", []Result{{Name: "identity/name", Status: StatusOK}})
+	rep.add("sub/messy", []Result{
+		{Name: "identity/email", Status: StatusFail, Message: "got a,\twant b\nreally", Fixable: true},
+		{Name: "branch/merged[x]", Status: StatusWarn, Message: "merged", Details: []string{"abc1234 subject"}},
+		{Name: "identity/name", Status: StatusOK},
+	})
+
+	var buf bytes.Buffer
+	if err := rep.writeFormat(&buf, "tsv"); err != nil {
+		t.Fatal(err)
+	}
+	want := "repo\tcheck\tstatus\tfixable\tmessage\n" +
+		"sub/messy\tidentity/email\tfail\ttrue\tgot a, want b really\n" +
+		"sub/messy\tbranch/merged[x]\twarn\tfalse\tmerged\n"
+	if buf.String() != want {
+		t.Errorf("tsv =\n%q\nwant\n%q", buf.String(), want)
+	}
+}
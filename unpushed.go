@@ -8,6 +8,8 @@ import (
 
 type UnpushedCheck struct{}
 
+func (c *UnpushedCheck) Name() string { return "unpushed" }
+
 func (c *UnpushedCheck) Check(repo *Repo) []Result {
 	maxAge := repo.Config.Thresholds.UnpushedMaxAge.Duration
 	if maxAge == 0 {
@@ -25,6 +27,10 @@ func (c *UnpushedCheck) Check(repo *Repo) []Result {
 
 	now := time.Now()
 	var results []Result
+	// Unpushed commits can still be reworded, so lint their messages too;
+	// a commit unpushed on several branches is linted once.
+	var subjects []commitSubject
+	seen := make(map[string]bool)
 	for _, branch := range branches {
 		// Skip branches handled by BranchCleanupCheck: PR checkouts
 		// and orphan branches by other authors.
@@ -60,6 +66,10 @@ func (c *UnpushedCheck) Check(repo *Repo) []Result {
 				continue
 			}
 			details = append(details, fmt.Sprintf("%s %s (%s ago)", line[:7], subject, formatDuration(now.Sub(t))))
+			if !seen[line[:40]] {
+				seen[line[:40]] = true
+				subjects = append(subjects, commitSubject{hash: line[:7], subject: subject})
+			}
 			if now.Sub(t) > maxAge {
 				stale++
 			}
@@ -81,13 +91,13 @@ func (c *UnpushedCheck) Check(repo *Repo) []Result {
 	}
 
 	if len(results) == 0 {
-		return []Result{{
+		results = []Result{{
 			Name:    "staleness/unpushed",
 			Status:  StatusOK,
 			Message: "no unpushed commits",
 		}}
 	}
-	return results
+	return append(results, commitMessageResults(repo.Config.CommitMessages, subjects)...)
 }
 
 func (c *UnpushedCheck) Fix(_ *Repo, results []Result) []Result {
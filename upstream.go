@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MissingUpstreamCheck catches a fork cloned without its parent remote.
+// Without one, ForkParentRemote finds nothing and the tracking and
+// gh-resolved checks silently skip. The fix adds the parent as upstream
+// using the configured protocol (or origin's, when none is configured).
+type MissingUpstreamCheck struct{}
+
+func (c *MissingUpstreamCheck) Name() string { return "fork-upstream" }
+
+func (c *MissingUpstreamCheck) Check(repo *Repo) []Result {
+	owner, _ := parseGitHubRepo(repo.RemoteURL("origin"))
+	if owner == "" {
+		return nil
+	}
+	parent := repo.ForkParent()
+	if parent == "" || repo.ForkParentRemote() != "" {
+		return nil
+	}
+
+	url := upstreamURL(repo, parent)
+	remotes, _ := repo.Remotes()
+	if hasRemote(remotes, "upstream") {
+		return []Result{{
+			Name:    "remote/fork-upstream",
+			Status:  StatusFail,
+			Message: fmt.Sprintf("origin is a fork of %s, but upstream points elsewhere (%s)", parent, repo.RemoteURL("upstream")),
+		}}
+	}
+	return []Result{{
+		Name:    "remote/fork-upstream",
+		Status:  StatusFail,
+		Message: fmt.Sprintf("origin is a fork of %s, but no remote points at it; git remote add upstream %s", parent, url),
+		Fixable: true,
+	}}
+}
+
+func (c *MissingUpstreamCheck) Fix(repo *Repo, results []Result) []Result {
+	var fixed []Result
+	for _, r := range results {
+		if r.Status != StatusFail || !r.Fixable {
+			fixed = append(fixed, r)
+			continue
+		}
+		url := upstreamURL(repo, repo.ForkParent())
+		if _, err := repo.Git("remote", "add", "upstream", url); err != nil {
+			fixed = append(fixed, r)
+			continue
+		}
+		fixed = append(fixed, Result{
+			Name:    r.Name,
+			Status:  StatusFix,
+			Message: fmt.Sprintf("added upstream %s", url),
+		})
+	}
+	return fixed
+}
+
+// upstreamURL builds the clone URL for the fork parent "owner/repo", using
+// the configured protocol or else origin's.
+func upstreamURL(repo *Repo, parent string) string {
+	protocol := repo.Config.Protocol
+	if protocol == "" {
+		protocol = urlProtocol(repo.RemoteURL("origin"))
+	}
+	owner, name, _ := strings.Cut(parent, "/")
+	return githubCloneURL(owner, name, protocol)
+}
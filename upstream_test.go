@@ -0,0 +1,48 @@
+package main
+
+import "testing"
+
+func TestMissingUpstreamAddsParent(t *testing.T) {
+	r := newTestRepo(t)
+	r.git("remote", "add", "origin", "https://github.com/me/repo.git")
+	r.cacheForkParent("acme/repo")
+	r.reload()
+
+	results := (&MissingUpstreamCheck{}).Check(r.Repo)
+	got, ok := resultByName(results, "remote/fork-upstream")
+	if !ok || got.Status != StatusFail || !got.Fixable {
+		t.Fatalf("fork-upstream = %+v, want fixable fail", results)
+	}
+
+	(&MissingUpstreamCheck{}).Fix(r.Repo, results)
+	if url := r.git("remote", "get-url", "upstream"); url != "https://github.com/acme/repo.git" {
+		t.Errorf("upstream url = %q, want parent over origin's protocol", url)
+	}
+	if results := (&MissingUpstreamCheck{}).Check(r.Repo); len(results) != 0 {
+		t.Errorf("after fix: got %+v, want none", results)
+	}
+}
+
+func TestMissingUpstreamNotAFork(t *testing.T) {
+	r := newTestRepo(t)
+	r.git("remote", "add", "origin", "https://github.com/me/repo.git")
+	r.cacheForkParent("none")
+	r.reload()
+
+	if results := (&MissingUpstreamCheck{}).Check(r.Repo); len(results) != 0 {
+		t.Errorf("non-fork: got %+v, want none", results)
+	}
+}
+
+func TestMissingUpstreamConflictingRemote(t *testing.T) {
+	r := newTestRepo(t)
+	r.git("remote", "add", "origin", "git@github.com:me/repo.git")
+	r.git("remote", "add", "upstream", "git@github.com:someone/repo.git")
+	r.cacheForkParent("acme/repo")
+	r.reload()
+
+	got, ok := resultByName((&MissingUpstreamCheck{}).Check(r.Repo), "remote/fork-upstream")
+	if !ok || got.Status != StatusFail || got.Fixable {
+		t.Errorf("fork-upstream = %+v, want non-fixable fail", got)
+	}
+}
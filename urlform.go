@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// URLFormCheck warns about GitHub remote URLs that deviate from the
+// canonical form: no trailing slash, and a ".git" suffix (or none, when
+// remoteURLForm is "bare"). Scripts that string-match remote URLs trip over
+// the variants even though git accepts them all.
+type URLFormCheck struct{}
+
+func (c *URLFormCheck) Name() string { return "url-form" }
+
+func (c *URLFormCheck) Check(repo *Repo) []Result {
+	remotes, _ := repo.Remotes()
+	if len(remotes) == 0 {
+		return nil
+	}
+
+	var results []Result
+	for _, name := range remotes {
+		url := repo.RemoteURL(name)
+		want := canonicalRemoteURL(url, repo.Config.RemoteURLForm)
+		if want == "" || want == url {
+			continue
+		}
+		results = append(results, Result{
+			Name:    fmt.Sprintf("remote/url-form[%s]", name),
+			Status:  StatusWarn,
+			Message: fmt.Sprintf("%s is not canonical, want %s", url, want),
+			Fixable: true,
+		})
+	}
+	return results
+}
+
+func (c *URLFormCheck) Fix(repo *Repo, results []Result) []Result {
+	var fixed []Result
+	for _, r := range results {
+		_, name := splitResultName(r.Name)
+		if !r.Fixable || name == "" {
+			fixed = append(fixed, r)
+			continue
+		}
+		want := canonicalRemoteURL(repo.RemoteURL(name), repo.Config.RemoteURLForm)
+		if want == "" {
+			fixed = append(fixed, r)
+			continue
+		}
+		if _, err := repo.Git("remote", "set-url", name, want); err != nil {
+			fixed = append(fixed, r)
+			continue
+		}
+		fixed = append(fixed, Result{
+			Name:    r.Name,
+			Status:  StatusFix,
+			Message: fmt.Sprintf("set to %s", want),
+		})
+	}
+	return fixed
+}
+
+// canonicalRemoteURL returns the canonical form of a GitHub remote URL, or
+// "" for URLs on other hosts, whose conventions git-lint doesn't know. form
+// is "bare" to drop the ".git" suffix; anything else requires it.
+func canonicalRemoteURL(url, form string) string {
+	if githubURLOwner(strings.TrimRight(url, "/")) == "" {
+		return ""
+	}
+	url = strings.TrimSuffix(strings.TrimRight(url, "/"), ".git")
+	if form == "bare" {
+		return url
+	}
+	return url + ".git"
+}
@@ -0,0 +1,40 @@
+package main
+
+import "testing"
+
+func TestCanonicalRemoteURL(t *testing.T) {
+	tests := []struct {
+		url, form, want string
+	}{
+		{"https://github.com/o/r.git", "", "https://github.com/o/r.git"},
+		{"https://github.com/o/r", "", "https://github.com/o/r.git"},
+		{"https://github.com/o/r/", "", "https://github.com/o/r.git"},
+		{"git@github.com:o/r.git/", "", "git@github.com:o/r.git"},
+		{"https://github.com/o/r.git", "bare", "https://github.com/o/r"},
+		{"https://gitlab.com/o/r", "", ""},
+	}
+	for _, tt := range tests {
+		if got := canonicalRemoteURL(tt.url, tt.form); got != tt.want {
+			t.Errorf("canonicalRemoteURL(%q, %q) = %q, want %q", tt.url, tt.form, got, tt.want)
+		}
+	}
+}
+
+func TestURLFormCheckFix(t *testing.T) {
+	r := newTestRepo(t)
+	r.git("remote", "add", "origin", "https://github.com/me/repo/")
+	r.reload()
+
+	results := (&URLFormCheck{}).Check(r.Repo)
+	got, ok := resultByName(results, "remote/url-form[origin]")
+	if !ok || got.Status != StatusWarn || !got.Fixable {
+		t.Fatalf("url-form = %+v, want fixable warn", results)
+	}
+	(&URLFormCheck{}).Fix(r.Repo, results)
+	if url := r.git("remote", "get-url", "origin"); url != "https://github.com/me/repo.git" {
+		t.Errorf("origin after fix = %q, want canonical", url)
+	}
+	if results := (&URLFormCheck{}).Check(r.Repo); len(results) != 0 {
+		t.Errorf("after fix: got %+v, want none", results)
+	}
+}
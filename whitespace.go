@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WhitespaceCheck runs `git diff --check` over the changes that haven't been
+// pushed yet: the current branch's unpushed commits plus the working tree.
+// git applies core.whitespace itself, so the rules match what `git apply
+// --whitespace=error` would reject. The check is opt-in via the whitespace
+// config setting, since legacy code often trips it.
+type WhitespaceCheck struct{}
+
+func (c *WhitespaceCheck) Name() string { return "whitespace" }
+
+func (c *WhitespaceCheck) Check(repo *Repo) []Result {
+	if !repo.Config.Whitespace {
+		return nil
+	}
+
+	var args []string
+	if repo.Ref != "" {
+		base := repo.MainBranch()
+		if base == "" {
+			return nil
+		}
+		if remote := mainRemoteRef(repo, base); remote != "" {
+			base = remote
+		}
+		args = []string{"diff", "--check", base + "..." + repo.Ref}
+	} else {
+		if _, err := repo.Git("rev-parse", "--verify", "--quiet", "HEAD"); err != nil {
+			return nil
+		}
+		// Compare against where HEAD forked from its upstream (or the
+		// remote main), so unpushed commits are covered too.
+		base := "HEAD"
+		upstream := "HEAD@{upstream}"
+		if _, err := repo.Git("rev-parse", "--verify", "--quiet", upstream); err != nil {
+			upstream = mainRemoteRef(repo, repo.MainBranch())
+		}
+		if upstream != "" {
+			if mb, err := repo.Git("merge-base", "HEAD", upstream); err == nil {
+				base = mb
+			}
+		}
+		args = []string{"diff", "--check", base}
+	}
+
+	// git diff --check exits 2 when it finds problems, so the error is
+	// expected; the output tells what went wrong.
+	out, _ := repo.Git(args...)
+	details := whitespaceProblems(out)
+	if len(details) > 0 {
+		return []Result{{
+			Name:    "files/whitespace",
+			Status:  StatusWarn,
+			Message: fmt.Sprintf("%d whitespace errors in unpushed changes", len(details)),
+			Details: details,
+		}}
+	}
+	return []Result{{
+		Name:    "files/whitespace",
+		Status:  StatusOK,
+		Message: "no whitespace errors in unpushed changes",
+	}}
+}
+
+func (c *WhitespaceCheck) Fix(_ *Repo, results []Result) []Result {
+	return results
+}
+
+// whitespaceProblems extracts the "file:line: problem" lines from
+// `git diff --check` output, dropping the offending content lines that
+// follow each one.
+func whitespaceProblems(out string) []string {
+	var problems []string
+	for _, line := range strings.Split(out, "\n") {
+		if line == "" || strings.HasPrefix(line, "+") || strings.HasPrefix(line, "-") {
+			continue
+		}
+		problems = append(problems, strings.TrimSuffix(line, "."))
+	}
+	return problems
+}
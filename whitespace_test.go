@@ -0,0 +1,46 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWhitespaceProblems(t *testing.T) {
+	out := "a.txt:2: trailing whitespace.\n+foo  \nb.txt:5: space before tab in indent.\n+ \tbar"
+	want := []string{"a.txt:2: trailing whitespace", "b.txt:5: space before tab in indent"}
+	if got := whitespaceProblems(out); !reflect.DeepEqual(got, want) {
+		t.Errorf("whitespaceProblems = %q, want %q", got, want)
+	}
+}
+
+func TestWhitespaceCheckUnpushedAndWorkingTree(t *testing.T) {
+	r := newTestRepo(t)
+	now := time.Now()
+	r.commit("a.txt", "clean\n", "base", now)
+	if results := (&WhitespaceCheck{}).Check(r.Repo); len(results) != 0 {
+		t.Fatalf("disabled: got %+v, want none", results)
+	}
+	r.Config.Whitespace = true
+
+	// Publish the base so only later commits count as unpushed.
+	r.git("update-ref", "refs/remotes/origin/main", "HEAD")
+
+	r.commit("b.txt", "trailing \n", "unpushed", now)
+	if err := os.WriteFile(filepath.Join(r.dir, "a.txt"), []byte("clean\ttab \n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, ok := resultByName((&WhitespaceCheck{}).Check(r.Repo), "files/whitespace")
+	if !ok || got.Status != StatusWarn || len(got.Details) != 2 {
+		t.Fatalf("files/whitespace = %+v, want warn with two errors", got)
+	}
+
+	// core.whitespace can turn the rule off.
+	r.git("config", "core.whitespace", "-trailing-space")
+	if got, _ := resultByName((&WhitespaceCheck{}).Check(r.Repo), "files/whitespace"); got.Status != StatusOK {
+		t.Errorf("with -trailing-space: files/whitespace = %+v, want ok", got)
+	}
+}
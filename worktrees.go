@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// WorktreeCheck looks after linked worktrees, which pile up when each
+// branch gets its own: a worktree whose directory was deleted without
+// `git worktree remove` is prunable, and a live one with uncommitted or
+// untracked changes is work that is easy to forget. The staleness check
+// covers the main worktree only, so each dirty worktree is reported once.
+type WorktreeCheck struct{}
+
+func (c *WorktreeCheck) Name() string { return "worktrees" }
+
+func (c *WorktreeCheck) Check(repo *Repo) []Result {
+	worktrees := linkedWorktrees(repo)
+	if len(worktrees) == 0 {
+		return nil
+	}
+	var results []Result
+	for _, wt := range worktrees {
+		name := filepath.Base(wt.path)
+		if wt.prunable {
+			results = append(results, Result{
+				Name:    fmt.Sprintf("worktree/prunable[%s]", name),
+				Status:  StatusWarn,
+				Message: fmt.Sprintf("worktree directory %s no longer exists", wt.path),
+				Fixable: true,
+			})
+			continue
+		}
+		if repo.Ref != "" {
+			continue
+		}
+		uncommitted, untracked := worktreeChanges(repo, wt.path)
+		changes := append(uncommitted, untracked...)
+		if len(changes) == 0 {
+			continue
+		}
+		msg := "uncommitted changes"
+		if wt.branch != "" {
+			msg = fmt.Sprintf("uncommitted changes on %s", wt.branch)
+		}
+		results = append(results, Result{
+			Name:    fmt.Sprintf("worktree/uncommitted[%s]", name),
+			Status:  StatusWarn,
+			Message: msg,
+			Details: changes,
+		})
+	}
+	if len(results) == 0 {
+		return []Result{{
+			Name:    "worktree/linked",
+			Status:  StatusOK,
+			Message: fmt.Sprintf("%d linked worktrees clean", len(worktrees)),
+		}}
+	}
+	return results
+}
+
+func (c *WorktreeCheck) Fix(repo *Repo, results []Result) []Result {
+	pruned := false
+	var fixed []Result
+	for _, r := range results {
+		if !r.Fixable || !strings.HasPrefix(r.Name, "worktree/prunable[") {
+			fixed = append(fixed, r)
+			continue
+		}
+		// One prune removes every prunable worktree.
+		if !pruned {
+			if _, err := repo.Git("worktree", "prune"); err != nil {
+				fixed = append(fixed, r)
+				continue
+			}
+			pruned = true
+		}
+		_, name := splitResultName(r.Name)
+		fixed = append(fixed, Result{
+			Name:    r.Name,
+			Status:  StatusFix,
+			Message: fmt.Sprintf("pruned worktree %s", name),
+		})
+	}
+	return fixed
+}
+
+// worktree is a linked worktree from `git worktree list --porcelain`.
+type worktree struct {
+	path     string
+	branch   string // short branch name; "" when detached
+	prunable bool
+}
+
+// linkedWorktrees returns the repo's worktrees except the main one, which
+// git always lists first. A worktree is prunable when git says so or its
+// directory is gone, unless it is locked.
+func linkedWorktrees(repo *Repo) []worktree {
+	out, err := repo.Git("worktree", "list", "--porcelain")
+	if err != nil {
+		return nil
+	}
+	var worktrees []worktree
+	// Entries are blocks of lines separated by a blank line.
+	for i, block := range strings.Split(out, "\n\n") {
+		if i == 0 {
+			continue
+		}
+		var wt worktree
+		locked := false
+		for _, line := range strings.Split(block, "\n") {
+			key, value, _ := strings.Cut(line, " ")
+			switch key {
+			case "worktree":
+				wt.path = value
+			case "branch":
+				wt.branch = strings.TrimPrefix(value, "refs/heads/")
+			case "prunable":
+				wt.prunable = true
+			case "locked":
+				locked = true
+			}
+		}
+		if wt.path == "" {
+			continue
+		}
+		if _, err := os.Stat(wt.path); os.IsNotExist(err) {
+			wt.prunable = true
+		}
+		wt.prunable = wt.prunable && !locked
+		worktrees = append(worktrees, wt)
+	}
+	return worktrees
+}
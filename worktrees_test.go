@@ -0,0 +1,50 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWorktreeCheck(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("a.txt", "a", "first", time.Now())
+	if results := (&WorktreeCheck{}).Check(r.Repo); len(results) != 0 {
+		t.Errorf("no linked worktrees: got %+v, want none", results)
+	}
+
+	base := t.TempDir()
+	live := filepath.Join(base, "live")
+	gone := filepath.Join(base, "gone")
+	r.git("worktree", "add", "-b", "live", live)
+	r.git("worktree", "add", "-b", "gone", gone)
+	if got, _ := resultByName((&WorktreeCheck{}).Check(r.Repo), "worktree/linked"); got.Status != StatusOK {
+		t.Errorf("clean worktrees = %+v, want ok", got)
+	}
+
+	if err := os.WriteFile(filepath.Join(live, "a.txt"), []byte("changed"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(live, "new.txt"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.RemoveAll(gone); err != nil {
+		t.Fatal(err)
+	}
+	results := (&WorktreeCheck{}).Check(r.Repo)
+	if got, _ := resultByName(results, "worktree/uncommitted[live]"); got.Status != StatusWarn || got.Fixable || len(got.Details) != 2 {
+		t.Errorf("dirty worktree = %+v, want non-fixable warn with the change and the untracked file", got)
+	}
+	if got, _ := resultByName(results, "worktree/prunable[gone]"); got.Status != StatusWarn || !got.Fixable {
+		t.Fatalf("deleted worktree = %+v, want fixable warn", got)
+	}
+
+	fixed := (&WorktreeCheck{}).Fix(r.Repo, results)
+	if got, _ := resultByName(fixed, "worktree/prunable[gone]"); got.Status != StatusFix {
+		t.Errorf("after fix = %+v, want fix", got)
+	}
+	if _, ok := resultByName((&WorktreeCheck{}).Check(r.Repo), "worktree/prunable[gone]"); ok {
+		t.Error("gone worktree still reported after prune")
+	}
+}